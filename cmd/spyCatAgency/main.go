@@ -2,10 +2,14 @@ package main
 
 import (
 	"spyCatAgency/internal/domain/usecases"
+	"spyCatAgency/internal/infrastructure/catapi"
 	"spyCatAgency/internal/infrastructure/database"
 	"spyCatAgency/internal/infrastructure/logger"
+	"spyCatAgency/internal/infrastructure/scheduler"
+	"spyCatAgency/internal/infrastructure/storage"
 	"spyCatAgency/internal/presentation/server"
 	"spyCatAgency/internal/presentation/server/handlers"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -23,15 +27,76 @@ func main() {
 	migrationPath := viper.GetString("MIGRATION_PATH")
 	database.RunDBMigration(migrationPath, dbSource)
 
+	viper.SetDefault("BREED_API_URL", "https://api.thecatapi.com/v1")
+	viper.SetDefault("BREED_FETCH_ATTEMPTS", 3)
+	viper.SetDefault("BREED_FETCH_BACKOFF", time.Second)
+	viper.SetDefault("BREED_REFRESH_INTERVAL", 24*time.Hour)
+
+	breedSnapshot, err := catapi.Snapshot()
+	if err != nil {
+		logger.Fatalf("Failed to read breed snapshot: %s", err.Error())
+	}
+
+	breedRepo := database.NewBreedRepository(logger, db)
+	breedClient := catapi.NewClient(logger, viper.GetString("BREED_API_URL"), viper.GetInt("BREED_FETCH_ATTEMPTS"), viper.GetDuration("BREED_FETCH_BACKOFF"))
+	breedUseCase := usecases.NewBreedUseCase(logger, breedRepo, breedClient)
+	if err := breedUseCase.Load(breedSnapshot); err != nil {
+		logger.Fatalf("Failed to load breed registry: %s", err.Error())
+	}
+	breedHandler := handlers.NewBreedHandler(logger, breedUseCase)
+	scheduler.Every(logger, "breed refresh", viper.GetDuration("BREED_REFRESH_INTERVAL"), breedUseCase.Refresh)
+
+	rankRepo := database.NewRankRepository(logger, db)
+	rankUseCase := usecases.NewRankUseCase(logger, rankRepo)
+	rankHandler := handlers.NewRankHandler(logger, rankUseCase)
+
+	txManager := database.NewTransactionManager(logger, db)
+
 	catRepo := database.NewCatRepository(logger, db)
-	catUseCase := usecases.NewCatUseCase(logger, catRepo)
+	catUseCase := usecases.NewCatUseCase(logger, catRepo, rankRepo, txManager)
 	catHandler := handlers.NewCatHandler(logger, catUseCase)
 
+	viper.SetDefault("SALARY_SCHEDULER_INTERVAL", time.Minute)
+	scheduler.Every(logger, "salary changes", viper.GetDuration("SALARY_SCHEDULER_INTERVAL"), catUseCase.ApplyScheduledSalaryChanges)
+
+	viper.SetDefault("PHOTO_STORAGE_PATH", "./data/photos")
+	photoStorage, err := storage.NewLocalStorage(viper.GetString("PHOTO_STORAGE_PATH"))
+	if err != nil {
+		logger.Fatalf("Failed to initialise photo storage: %s", err.Error())
+	}
+	photoUseCase := usecases.NewPhotoUseCase(logger, catRepo, photoStorage)
+	photoHandler := handlers.NewPhotoHandler(logger, photoUseCase)
+
+	payrollRepo := database.NewPayrollRepository(logger, db)
+	payrollUseCase := usecases.NewPayrollUseCase(logger, payrollRepo, catRepo)
+	payrollHandler := handlers.NewPayrollHandler(logger, payrollUseCase)
+
+	skillRepo := database.NewSkillRepository(logger, db)
+	skillUseCase := usecases.NewSkillUseCase(logger, skillRepo, catRepo)
+	skillHandler := handlers.NewSkillHandler(logger, skillUseCase)
+
 	missionRepo := database.NewMissonRepository(logger, db)
-	missionUseCase := usecases.NewMissionUseCase(logger, missionRepo, catRepo)
+	missionTypeRepo := database.NewMissionTypeRepository(logger, db)
+	missionTypeUseCase := usecases.NewMissionTypeUseCase(logger, missionTypeRepo)
+	missionTypeHandler := handlers.NewMissionTypeHandler(logger, missionTypeUseCase)
+
+	viper.SetDefault("LEAVE_CHECK_HORIZON", 30*24*time.Hour)
+	missionUseCase := usecases.NewMissionUseCase(logger, missionRepo, catRepo, skillRepo, missionTypeRepo, txManager, viper.GetDuration("LEAVE_CHECK_HORIZON"))
+
+	viper.SetDefault("OVERDUE_CHECK_INTERVAL", time.Minute)
+	scheduler.Every(logger, "overdue missions", viper.GetDuration("OVERDUE_CHECK_INTERVAL"), missionUseCase.FlagOverdueMissions)
+
 	missionHandler := handlers.NewMisionHandler(logger, missionUseCase)
 
-	app := server.New(logger, catHandler, missionHandler)
+	templateRepo := database.NewMissionTemplateRepository(logger, db)
+	templateUseCase := usecases.NewMissionTemplateUseCase(logger, templateRepo, missionTypeRepo, missionUseCase)
+	templateHandler := handlers.NewMissionTemplateHandler(logger, templateUseCase)
+
+	searchRepo := database.NewSearchRepository(logger, db)
+	searchUseCase := usecases.NewSearchUseCase(logger, searchRepo)
+	searchHandler := handlers.NewSearchHandler(logger, searchUseCase)
+
+	app := server.New(logger, catHandler, missionHandler, breedHandler, skillHandler, rankHandler, payrollHandler, photoHandler, searchHandler, templateHandler, missionTypeHandler, breedUseCase)
 
 	port := viper.GetString("SERVER_PORT")
 	app.Run(port)
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Breed struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Origin    string    `json:"origin"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
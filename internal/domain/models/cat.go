@@ -3,10 +3,63 @@ package models
 import "time"
 
 type Cat struct {
-	ID                uint      `json:"id"`
-	Name              string    `json:"name"`
-	YearsOfExperience uint      `json:"years_of_experience"`
-	Breed             string    `json:"breed"`
-	Salary            float64   `json:"salary"`
-	CreatedAt         time.Time `json:"created_at"`
+	ID                uint       `json:"id"`
+	Name              string     `json:"name"`
+	YearsOfExperience uint       `json:"years_of_experience"`
+	Breed             string     `json:"breed"`
+	Salary            float64    `json:"salary"`
+	Rank              string     `json:"rank"`
+	CreatedAt         time.Time  `json:"created_at"`
+	HiredAt           time.Time  `json:"hired_at"`
+	TerminatedAt      *time.Time `json:"terminated_at"`
+	TerminationReason string     `json:"termination_reason"`
+	PhotoKey          string     `json:"photo_key"`
+	PhotoContentType  string     `json:"photo_content_type"`
+}
+
+func (c *Cat) IsTerminated() bool {
+	return c.TerminatedAt != nil
+}
+
+// EmploymentPeriod is one stint of a cat at the agency; rehiring opens a new one.
+// EndedAt is nil while the cat is employed.
+type EmploymentPeriod struct {
+	CatID     uint       `json:"cat_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+func (c *Cat) HasPhoto() bool {
+	return c.PhotoKey != ""
+}
+
+type CatListQuery struct {
+	Limit                uint
+	Cursor               string
+	Breed                string
+	MinYearsOfExperience *uint
+	MaxYearsOfExperience *uint
+	MinSalary            *float64
+	MaxSalary            *float64
+	HiredAfter           *time.Time
+	HiredBefore          *time.Time
+	SortBy               string
+	SortDesc             bool
+	WithTotalCount       bool
+	IncludeTerminated    bool
+	AvailableOn          *time.Time
+}
+
+type CatPage struct {
+	List       []Cat
+	NextCursor string
+	TotalCount *uint
+}
+
+type CatUpdate struct {
+	Name              *string
+	YearsOfExperience *uint
+	Breed             *string
+	Salary            *float64
+	Rank              *string
 }
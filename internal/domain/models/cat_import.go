@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	MaxImportRows = 1000
+
+	// MaxImportSize bounds the import request body; a thousand rows of cat
+	// fields fit comfortably.
+	MaxImportSize = 1 << 20
+)
+
+const (
+	ImportStatusImported = "imported"
+	ImportStatusFailed   = "failed"
+	ImportStatusSkipped  = "skipped"
+)
+
+type CatImportRow struct {
+	Row    uint
+	Cat    Cat
+	Status string
+	Errors map[string]string
+}
+
+func (r *CatImportRow) IsValid() bool {
+	return len(r.Errors) == 0
+}
+
+type CatImportReport struct {
+	Atomic   bool
+	Imported uint
+	Failed   uint
+	Rows     []CatImportRow
+}
+
+// DuplicateKey identifies rows describing the same cat within one import.
+func (r *CatImportRow) DuplicateKey() string {
+	return fmt.Sprintf("%s|%s|%d|%.2f|%s",
+		strings.ToLower(strings.TrimSpace(r.Cat.Name)),
+		strings.ToLower(strings.TrimSpace(r.Cat.Breed)),
+		r.Cat.YearsOfExperience,
+		r.Cat.Salary,
+		strings.ToLower(strings.TrimSpace(r.Cat.Rank)),
+	)
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Handover records a cat leaving a mission, either for another cat or for
+// nobody, together with the target notes it left behind.
+type Handover struct {
+	ID             uint           `json:"id"`
+	MissionID      uint           `json:"mission_id"`
+	FromCatID      uint           `json:"from_cat_id"`
+	ToCatID        *uint          `json:"to_cat_id"`
+	FromAssignedAt *time.Time     `json:"from_assigned_at"`
+	Reason         string         `json:"reason"`
+	Actor          string         `json:"actor"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Notes          []HandoverNote `json:"notes"`
+}
+
+type HandoverNote struct {
+	TargetID   uint   `json:"target_id"`
+	TargetName string `json:"target_name"`
+	Notes      string `json:"notes"`
+}
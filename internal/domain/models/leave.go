@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Leave is an absence period of a cat. Both StartDate and EndDate are inclusive calendar days.
+type Leave struct {
+	ID        uint      `json:"id"`
+	CatID     uint      `json:"cat_id"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
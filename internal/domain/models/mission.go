@@ -2,21 +2,157 @@ package models
 
 import "time"
 
+// IsValidMissionName applies the rule the API enforces on mission names: ASCII
+// letters only.
+func IsValidMissionName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
 type Mission struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	CatId       *uint     `json:"cat_id"`
-	TargetList  []Target  `json:"target_list"`
-	IsCompleted bool      `json:"is_completed"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID             uint               `json:"id"`
+	Name           string             `json:"name"`
+	Type           string             `json:"type"`
+	CatId          *uint              `json:"cat_id"`
+	AssignedAt     *time.Time         `json:"assigned_at"`
+	TargetList     []Target           `json:"target_list"`
+	RequiredSkills []SkillRequirement `json:"required_skills"`
+	State          string             `json:"state"`
+	IsCompleted    bool               `json:"is_completed"`
+	CreatedAt      time.Time          `json:"created_at"`
+	CompletedAt    *time.Time         `json:"completed_at"`
+	ClosedAt       *time.Time         `json:"closed_at"`
+	DueAt          *time.Time         `json:"due_at"`
+	OverdueAt      *time.Time         `json:"overdue_at"`
+	ClonedFromID   *uint              `json:"cloned_from_id"`
+	Team           []MissionMember    `json:"team"`
+	Budget         *MissionBudget     `json:"budget"`
+}
+
+// IsOverdue reports whether the mission is still open past its deadline.
+func (m *Mission) IsOverdue(now time.Time) bool {
+	return m.DueAt != nil && now.After(*m.DueAt) && !m.IsClosed()
+}
+
+// IsClosed reports whether the mission reached a terminal state.
+func (m *Mission) IsClosed() bool {
+	return IsTerminalMissionState(m.State)
+}
+
+// HasTarget reports whether the target is still on the mission.
+func (m *Mission) HasTarget(targetID uint) bool {
+	return m.Target(targetID) != nil
+}
+
+// Target returns the mission's target with the given id, or nil.
+func (m *Mission) Target(targetID uint) *Target {
+	for i := range m.TargetList {
+		if m.TargetList[i].ID == targetID {
+			return &m.TargetList[i]
+		}
+	}
+	return nil
 }
 
 type Target struct {
-	ID          uint      `json:"id"`
-	MissionID   uint      `json:"mission_id" `
-	Name        string    `json:"name"`
-	Country     string    `json:"country"`
-	Notes       string    `json:"notes"`
-	IsCompleted bool      `json:"is_completed"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uint       `json:"id"`
+	MissionID   uint       `json:"mission_id" `
+	Name        string     `json:"name"`
+	Country     string     `json:"country"`
+	Notes       string     `json:"notes"`
+	IsCompleted bool       `json:"is_completed"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	DueAt       *time.Time `json:"due_at"`
+	CompletedBy *uint      `json:"completed_by"`
+}
+
+const (
+	MissionStateDraft      = "draft"
+	MissionStateAssigned   = "assigned"
+	MissionStateInProgress = "in_progress"
+	MissionStateCompleted  = "completed"
+	MissionStateAborted    = "aborted"
+	MissionStateFailed     = "failed"
+
+	// MissionStatusActive filters missions that currently occupy their cat.
+	MissionStatusActive = "active"
+)
+
+var missionTransitions = map[string][]string{
+	MissionStateDraft:      {MissionStateAssigned, MissionStateAborted},
+	MissionStateAssigned:   {MissionStateDraft, MissionStateInProgress, MissionStateCompleted, MissionStateAborted},
+	MissionStateInProgress: {MissionStateDraft, MissionStateCompleted, MissionStateFailed, MissionStateAborted},
+}
+
+func CanTransitionMission(from, to string) bool {
+	for _, state := range missionTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+func IsTerminalMissionState(state string) bool {
+	return state == MissionStateCompleted || state == MissionStateAborted || state == MissionStateFailed
+}
+
+type MissionTransition struct {
+	ID        uint      `json:"id"`
+	MissionID uint      `json:"mission_id"`
+	FromState string    `json:"from_state"`
+	ToState   string    `json:"to_state"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CatMissionsQuery struct {
+	CatID  uint
+	Status string
+	From   *time.Time
+	To     *time.Time
+}
+
+type MissionListQuery struct {
+	Limit          uint
+	Cursor         string
+	Status         string
+	Assigned       *bool
+	CatID          *uint
+	Country        string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	Overdue        *bool
+	DueBefore      *time.Time
+	WithTotalCount bool
+}
+
+type MissionPage struct {
+	List       []Mission
+	NextCursor string
+	TotalCount *uint
+}
+
+type TargetUpdate struct {
+	Notes *string
+	DueAt *time.Time
+}
+
+// MissionClone holds the caller's overrides when copying a mission. An empty
+// TargetIDs copies every target of the source mission.
+type MissionClone struct {
+	Name      string
+	CatID     *uint
+	TargetIDs []uint
 }
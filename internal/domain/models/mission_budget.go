@@ -0,0 +1,72 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Policies applied when a new expense would take a mission over its budget.
+const (
+	BudgetPolicyWarn  = "warn"
+	BudgetPolicyBlock = "block"
+
+	DefaultBudgetPolicy = BudgetPolicyWarn
+)
+
+const (
+	ExpenseCategoryTravel     = "travel"
+	ExpenseCategoryLodging    = "lodging"
+	ExpenseCategoryEquipment  = "equipment"
+	ExpenseCategoryInformants = "informants"
+	ExpenseCategoryOther      = "other"
+)
+
+// MissionBudget is the spending limit of a mission. Spent is the sum of all
+// expenses recorded against it.
+type MissionBudget struct {
+	MissionID uint      `json:"mission_id"`
+	Amount    float64   `json:"amount"`
+	Policy    string    `json:"policy"`
+	Spent     float64   `json:"spent"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Remaining returns what is left of the budget, negative once it is overspent.
+func (b *MissionBudget) Remaining() float64 {
+	return float64(Cents(b.Amount)-Cents(b.Spent)) / 100
+}
+
+// Covers reports whether an expense of the given amount still fits in the budget.
+func (b *MissionBudget) Covers(amount float64) bool {
+	return Cents(b.Spent)+Cents(amount) <= Cents(b.Amount)
+}
+
+// Cents converts an amount of money to whole cents. Budgets and expenses are
+// compared in cents so that float rounding cannot decide whether an expense fits.
+func Cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// IsWholeCents reports whether the amount has no fraction of a cent.
+func IsWholeCents(amount float64) bool {
+	return math.Abs(amount*100-math.Round(amount*100)) < 1e-6
+}
+
+// Expense is money spent on a mission. SpentOn is a calendar day.
+type Expense struct {
+	ID         uint      `json:"id"`
+	MissionID  uint      `json:"mission_id"`
+	Amount     float64   `json:"amount"`
+	Category   string    `json:"category"`
+	SpentOn    time.Time `json:"spent_on"`
+	ReceiptRef string    `json:"receipt_ref"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExpenseResult is a recorded expense together with the budget it was charged
+// to. OverBudget is set when a warn policy let the expense through anyway.
+type ExpenseResult struct {
+	Expense    Expense
+	Budget     *MissionBudget
+	OverBudget bool
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	MemberRoleLead     = "lead"
+	MemberRoleSupport  = "support"
+	MemberRoleObserver = "observer"
+)
+
+// MissionMember is a cat on a mission's team. The lead is also kept in
+// Mission.CatId so that single-cat clients keep working.
+type MissionMember struct {
+	MissionID uint      `json:"mission_id"`
+	CatID     uint      `json:"cat_id"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+// Member returns the team member with the given cat id, or nil if the cat is not on the team.
+func (m *Mission) Member(catID uint) *MissionMember {
+	for i := range m.Team {
+		if m.Team[i].CatID == catID {
+			return &m.Team[i]
+		}
+	}
+	return nil
+}
+
+// IsAssigned reports whether any cat is on the mission, as lead or as a
+// supporting team member.
+func (m *Mission) IsAssigned() bool {
+	return m.CatId != nil || len(m.Team) > 0
+}
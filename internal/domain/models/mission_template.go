@@ -0,0 +1,41 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// TemplatePlaceholderName is replaced by the template's name in its name
+// pattern. Mission names are letters only, so there is no date placeholder.
+const TemplatePlaceholderName = "{template}"
+
+// MissionTemplate is a reusable blueprint for recurring operations. Notes are
+// copied into every target stub that has no notes of its own.
+type MissionTemplate struct {
+	ID          uint             `json:"id"`
+	Name        string           `json:"name"`
+	NamePattern string           `json:"name_pattern"`
+	MissionType string           `json:"mission_type"`
+	Notes       string           `json:"notes"`
+	Targets     []TemplateTarget `json:"targets"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type TemplateTarget struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Notes   string `json:"notes"`
+}
+
+// MissionName renders the name pattern for a mission created from the template.
+func (t *MissionTemplate) MissionName() string {
+	return strings.ReplaceAll(t.NamePattern, TemplatePlaceholderName, t.Name)
+}
+
+// TemplateInstance holds what the caller adds to a template when creating a mission from it.
+type TemplateInstance struct {
+	Name  string
+	CatID *uint
+	DueAt *time.Time
+}
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestMissionTemplateMissionName(t *testing.T) {
+	tests := []struct {
+		name     string
+		template MissionTemplate
+		want     string
+	}{
+		{
+			name:     "placeholder",
+			template: MissionTemplate{Name: "Nightfall", NamePattern: "Operation{template}"},
+			want:     "OperationNightfall",
+		},
+		{
+			name:     "repeated placeholder",
+			template: MissionTemplate{Name: "Echo", NamePattern: "{template}{template}"},
+			want:     "EchoEcho",
+		},
+		{
+			name:     "no placeholder",
+			template: MissionTemplate{Name: "Nightfall", NamePattern: "Sweep"},
+			want:     "Sweep",
+		},
+		{
+			name:     "unknown placeholder is kept",
+			template: MissionTemplate{Name: "Nightfall", NamePattern: "{template}{date}"},
+			want:     "Nightfall{date}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.template.MissionName(); got != tt.want {
+				t.Errorf("MissionName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
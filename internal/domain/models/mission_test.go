@@ -0,0 +1,33 @@
+package models
+
+import "testing"
+
+func TestCanTransitionMission(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{MissionStateDraft, MissionStateAssigned, true},
+		{MissionStateDraft, MissionStateAborted, true},
+		{MissionStateDraft, MissionStateInProgress, false},
+		{MissionStateDraft, MissionStateCompleted, false},
+		{MissionStateAssigned, MissionStateDraft, true},
+		{MissionStateAssigned, MissionStateInProgress, true},
+		{MissionStateAssigned, MissionStateCompleted, true},
+		{MissionStateAssigned, MissionStateFailed, false},
+		{MissionStateInProgress, MissionStateCompleted, true},
+		{MissionStateInProgress, MissionStateFailed, true},
+		{MissionStateInProgress, MissionStateAssigned, false},
+		{MissionStateCompleted, MissionStateDraft, false},
+		{MissionStateAborted, MissionStateAssigned, false},
+		{MissionStateFailed, MissionStateInProgress, false},
+		{MissionStateDraft, MissionStateDraft, false},
+		{"unknown", MissionStateAssigned, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransitionMission(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionMission(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+const DefaultMissionType = "standard"
+
+// MissionType carries the policy every mission of that type has to follow.
+type MissionType struct {
+	Code                        string `json:"code"`
+	Name                        string `json:"name"`
+	MinTargets                  uint   `json:"min_targets"`
+	MaxTargets                  uint   `json:"max_targets"`
+	AllowDeleteCompletedTargets bool   `json:"allow_delete_completed_targets"`
+	AllowDeleteAssigned         bool   `json:"allow_delete_assigned"`
+}
+
+func (t *MissionType) AllowsTargetCount(count int) bool {
+	return count >= int(t.MinTargets) && count <= int(t.MaxTargets)
+}
+
+// AllowsTargetRemoval reports whether a mission may be left with count targets
+// after a delete. Only the minimum applies, so missions created under a looser
+// policy can still shrink towards the current maximum.
+func (t *MissionType) AllowsTargetRemoval(count int) bool {
+	return count >= int(t.MinTargets)
+}
+
+// AllowsTargetAddition reports whether a mission may grow to count targets.
+// Only the maximum applies, so missions below the current minimum can still
+// catch up.
+func (t *MissionType) AllowsTargetAddition(count int) bool {
+	return count <= int(t.MaxTargets)
+}
+
+type MissionTypeUpdate struct {
+	Name                        *string
+	MinTargets                  *uint
+	MaxTargets                  *uint
+	AllowDeleteCompletedTargets *bool
+	AllowDeleteAssigned         *bool
+}
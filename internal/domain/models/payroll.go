@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type PayrollRun struct {
+	ID          uint          `json:"id"`
+	PeriodStart time.Time     `json:"period_start"`
+	PeriodEnd   time.Time     `json:"period_end"`
+	Total       float64       `json:"total"`
+	Lines       []PayrollLine `json:"lines"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type PayrollLine struct {
+	ID           uint    `json:"id"`
+	RunID        uint    `json:"run_id"`
+	CatID        uint    `json:"cat_id"`
+	CatName      string  `json:"cat_name"`
+	Salary       float64 `json:"salary"`
+	DaysEmployed uint    `json:"days_employed"`
+	DaysInPeriod uint    `json:"days_in_period"`
+	Amount       float64 `json:"amount"`
+}
@@ -0,0 +1,12 @@
+package models
+
+const (
+	MaxPhotoSize = 5 << 20
+
+	// MaxPhotoPixels bounds the decoded image, since a small compressed file
+	// can declare dimensions that take gigabytes to decode.
+	MaxPhotoPixels = 25_000_000
+
+	// MaxPhotoRequestSize leaves room for the multipart framing around the photo.
+	MaxPhotoRequestSize = MaxPhotoSize + 1<<20
+)
@@ -0,0 +1,25 @@
+package models
+
+const DefaultRank = "recruit"
+
+type Rank struct {
+	Code                 string  `json:"code"`
+	Name                 string  `json:"name"`
+	Position             uint    `json:"position"`
+	MinYearsOfExperience uint    `json:"min_years_of_experience"`
+	MinCompletedMissions uint    `json:"min_completed_missions"`
+	MinSalary            float64 `json:"min_salary"`
+	MaxSalary            float64 `json:"max_salary"`
+}
+
+func (r *Rank) AllowsSalary(salary float64) bool {
+	return salary >= r.MinSalary && salary <= r.MaxSalary
+}
+
+type RankUpdate struct {
+	Name                 *string
+	MinYearsOfExperience *uint
+	MinCompletedMissions *uint
+	MinSalary            *float64
+	MaxSalary            *float64
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Outcomes a salary change is resolved with. A change without an outcome is pending.
+const (
+	SalaryChangeApplied   = "applied"
+	SalaryChangeCancelled = "cancelled"
+)
+
+// SalaryChange is an entry of the append-only salary ledger. OldSalary,
+// AppliedAt, CancelledAt and CancelReason come from the change's outcome.
+type SalaryChange struct {
+	ID           uint       `json:"id"`
+	CatID        uint       `json:"cat_id"`
+	OldSalary    *float64   `json:"old_salary"`
+	NewSalary    float64    `json:"new_salary"`
+	Reason       string     `json:"reason"`
+	EffectiveAt  time.Time  `json:"effective_at"`
+	AppliedAt    *time.Time `json:"applied_at"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CancelReason string     `json:"cancel_reason"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (c *SalaryChange) IsApplied() bool {
+	return c.AppliedAt != nil
+}
+
+func (c *SalaryChange) IsPending() bool {
+	return c.AppliedAt == nil && c.CancelledAt == nil
+}
+
+// SalaryChangeOutcome resolves a pending salary change exactly once.
+type SalaryChangeOutcome struct {
+	ChangeID  uint
+	Outcome   string
+	OldSalary *float64
+	Note      string
+}
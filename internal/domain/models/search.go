@@ -0,0 +1,22 @@
+package models
+
+const (
+	SearchHitCat     = "cat"
+	SearchHitMission = "mission"
+	SearchHitTarget  = "target"
+)
+
+type SearchQuery struct {
+	Text  string
+	Types []string
+	Limit uint
+}
+
+type SearchHit struct {
+	Type      string  `json:"type"`
+	ID        uint    `json:"id"`
+	MissionID *uint   `json:"mission_id"`
+	Title     string  `json:"title"`
+	Snippet   string  `json:"snippet"`
+	Rank      float64 `json:"rank"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	MinSkillLevel = 1
+	MaxSkillLevel = 5
+)
+
+type Skill struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CatSkill struct {
+	SkillID   uint   `json:"skill_id"`
+	SkillName string `json:"skill_name"`
+	Level     uint   `json:"level"`
+}
+
+type SkillRequirement struct {
+	SkillID   uint   `json:"skill_id"`
+	SkillName string `json:"skill_name"`
+	MinLevel  uint   `json:"min_level"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type MissionRef struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type CatStats struct {
+	CatID                  uint           `json:"cat_id"`
+	MissionsCompleted      uint           `json:"missions_completed"`
+	TargetsCompleted       uint           `json:"targets_completed"`
+	MedianTargetCompletion *time.Duration `json:"median_target_completion"`
+	Countries              []string       `json:"countries"`
+	CurrentMission         *MissionRef    `json:"current_mission"`
+}
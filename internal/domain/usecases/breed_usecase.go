@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/logger"
+	"sync"
+)
+
+type (
+	BreedRepositoryInterface interface {
+		Upsert(breeds []models.Breed) error
+		List() ([]models.Breed, error)
+	}
+
+	BreedSourceInterface interface {
+		FetchBreeds() ([]models.Breed, error)
+	}
+
+	breedUseCase struct {
+		logger          logger.Logger
+		breedRepository BreedRepositoryInterface
+		breedSource     BreedSourceInterface
+
+		mu     sync.RWMutex
+		breeds []models.Breed
+		names  map[string]struct{}
+	}
+)
+
+func NewBreedUseCase(customLogger logger.Logger, breedRepo BreedRepositoryInterface, breedSource BreedSourceInterface) *breedUseCase {
+	return &breedUseCase{
+		logger:          customLogger,
+		breedRepository: breedRepo,
+		breedSource:     breedSource,
+		breeds:          []models.Breed{},
+		names:           map[string]struct{}{},
+	}
+}
+
+// Load fills the registry from the database, seeding it with the snapshot when the table is empty.
+func (uc *breedUseCase) Load(snapshot []models.Breed) error {
+	breeds, err := uc.breedRepository.List()
+
+	if err != nil {
+		return err
+	}
+
+	if len(breeds) == 0 {
+		uc.logger.Infof("Breed registry is empty, seeding %d breeds from snapshot", len(snapshot))
+
+		if err := uc.breedRepository.Upsert(snapshot); err != nil {
+			return err
+		}
+
+		breeds, err = uc.breedRepository.List()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	uc.setBreeds(breeds)
+
+	return nil
+}
+
+// Refresh pulls the latest breed list from the remote source and stores it in the registry.
+func (uc *breedUseCase) Refresh() error {
+	fetched, err := uc.breedSource.FetchBreeds()
+
+	if err != nil {
+		return err
+	}
+
+	if err := uc.breedRepository.Upsert(fetched); err != nil {
+		return err
+	}
+
+	breeds, err := uc.breedRepository.List()
+
+	if err != nil {
+		return err
+	}
+
+	uc.setBreeds(breeds)
+	uc.logger.Infof("Breed registry refreshed, %d breeds known", len(breeds))
+
+	return nil
+}
+
+func (uc *breedUseCase) List() ([]models.Breed, error) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	list := make([]models.Breed, len(uc.breeds))
+	copy(list, uc.breeds)
+
+	return list, nil
+}
+
+func (uc *breedUseCase) IsKnownBreed(name string) bool {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	_, ok := uc.names[name]
+	return ok
+}
+
+func (uc *breedUseCase) setBreeds(breeds []models.Breed) {
+	names := make(map[string]struct{}, len(breeds))
+	for _, breed := range breeds {
+		names[breed.Name] = struct{}{}
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.breeds = breeds
+	uc.names = names
+}
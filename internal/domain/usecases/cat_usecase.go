@@ -1,36 +1,69 @@
 package usecases
 
 import (
+	"fmt"
 	"spyCatAgency/internal/domain/models"
 	"spyCatAgency/internal/infrastructure/apperrors"
 	"spyCatAgency/internal/infrastructure/logger"
+	"time"
+)
 
-	"github.com/lib/pq"
+const (
+	DefaultPageLimit = 20
+	MaxBulkStatsCats = 100
 )
 
 type (
 	CatRepositoryInterface interface {
 		Add(cat models.Cat) (*models.Cat, error)
-		Delete(id uint) error
-		Update(id uint, salary float64) (*models.Cat, error)
-		List() ([]models.Cat, error)
+		AddMany(cats []models.Cat) ([]models.Cat, error)
+		Terminate(id uint, reason string) (*models.Cat, error)
+		Rehire(id uint) (*models.Cat, error)
+		HasActiveMission(id uint) (bool, error)
+		SetPhoto(id uint, key, contentType string) (*models.Cat, error)
+		Update(id uint, update models.CatUpdate) (*models.Cat, error)
+		List(query models.CatListQuery) (*models.CatPage, error)
 		Get(id uint) (*models.Cat, error)
+		GetForUpdate(id uint) (*models.Cat, error)
+		AddSalaryChange(change models.SalaryChange) (*models.SalaryChange, error)
+		ListSalaryHistory(catID uint) ([]models.SalaryChange, error)
+		ListDueSalaryChanges(now time.Time) ([]models.SalaryChange, error)
+		LockSalaryChange(id uint) (*models.SalaryChange, error)
+		ResolveSalaryChange(outcome models.SalaryChangeOutcome) error
+		CancelPendingSalaryChanges(catID uint, note string) error
+		AddLeave(leave models.Leave) (*models.Leave, error)
+		GetLeave(id uint) (*models.Leave, error)
+		DeleteLeave(id uint) error
+		ListLeaves(catID uint) ([]models.Leave, error)
+		ListOverlappingLeaves(catID uint, from time.Time, to *time.Time) ([]models.Leave, error)
+		ListStats(catIDs []uint) ([]models.CatStats, error)
+		ListEmployedBetween(start, end time.Time) ([]models.Cat, error)
+		ListEmploymentPeriods(start, end time.Time) ([]models.EmploymentPeriod, error)
+		ListAppliedSalaryChanges(until time.Time) ([]models.SalaryChange, error)
 	}
 
 	catUseCase struct {
-		logger        logger.Logger
-		catRepository CatRepositoryInterface
+		logger         logger.Logger
+		catRepository  CatRepositoryInterface
+		rankRepository RankRepositoryInterface
+		txManager      TransactionManagerInterface
 	}
 )
 
-func NewCatUseCase(customLogger logger.Logger, catRepo CatRepositoryInterface) *catUseCase {
+func NewCatUseCase(customLogger logger.Logger, catRepo CatRepositoryInterface, rankRepo RankRepositoryInterface, txManager TransactionManagerInterface) *catUseCase {
 	return &catUseCase{
-		logger:        customLogger,
-		catRepository: catRepo,
+		logger:         customLogger,
+		catRepository:  catRepo,
+		rankRepository: rankRepo,
+		txManager:      txManager,
 	}
 }
 
 func (uc *catUseCase) HireCat(cat models.Cat) (*models.Cat, error) {
+	if err := uc.checkHire(&cat); err != nil {
+		return nil, err
+	}
+
 	hiredCat, err := uc.catRepository.Add(cat)
 
 	if err != nil {
@@ -41,58 +74,433 @@ func (uc *catUseCase) HireCat(cat models.Cat) (*models.Cat, error) {
 
 }
 
-func (uc *catUseCase) FireCat(catID uint) error {
+// ImportCats hires every valid row. In atomic mode nothing is written unless all
+// rows pass validation, and the inserts share one transaction. A row repeating
+// an earlier one fails as a duplicate.
+func (uc *catUseCase) ImportCats(rows []models.CatImportRow, atomic bool) (*models.CatImportReport, error) {
+	if len(rows) == 0 {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Nothing to import"))
+		return nil, apperrors.ErrBadRequestf("Nothing to import")
+	}
+
+	if len(rows) > models.MaxImportRows {
+		msg := fmt.Sprintf("Cannot import more than %d cats at once", models.MaxImportRows)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return nil, apperrors.ErrBadRequestf(msg)
+	}
+
+	report := &models.CatImportReport{Atomic: atomic, Rows: rows}
+
+	seen := make(map[string]uint, len(rows))
+
+	for i := range rows {
+		if !rows[i].IsValid() {
+			continue
+		}
+
+		key := rows[i].DuplicateKey()
+		if first, ok := seen[key]; ok {
+			rows[i].Errors = map[string]string{"error": fmt.Sprintf("Duplicate of row %d", first)}
+			continue
+		}
+		seen[key] = rows[i].Row
+
+		if err := uc.checkHire(&rows[i].Cat); err != nil {
+			rows[i].Errors = map[string]string{"error": err.Error()}
+		}
+	}
+
+	valid := make([]int, 0, len(rows))
+	for i := range rows {
+		if rows[i].IsValid() {
+			valid = append(valid, i)
+		} else {
+			rows[i].Status = models.ImportStatusFailed
+			report.Failed++
+		}
+	}
+
+	if atomic {
+		if report.Failed > 0 {
+			for _, i := range valid {
+				rows[i].Status = models.ImportStatusSkipped
+			}
+			return report, nil
+		}
+
+		cats := make([]models.Cat, 0, len(valid))
+		for _, i := range valid {
+			cats = append(cats, rows[i].Cat)
+		}
+
+		hired, err := uc.catRepository.AddMany(cats)
+		if err != nil {
+			return nil, err
+		}
+
+		for n, i := range valid {
+			rows[i].Cat = hired[n]
+			rows[i].Status = models.ImportStatusImported
+			report.Imported++
+		}
+
+		return report, nil
+	}
+
+	for _, i := range valid {
+		hired, err := uc.catRepository.Add(rows[i].Cat)
+		if err != nil {
+			rows[i].Status = models.ImportStatusFailed
+			rows[i].Errors = map[string]string{"error": err.Error()}
+			report.Failed++
+			continue
+		}
+
+		rows[i].Cat = *hired
+		rows[i].Status = models.ImportStatusImported
+		report.Imported++
+	}
+
+	return report, nil
+}
+
+// FireCat terminates the cat and cancels its pending salary changes.
+func (uc *catUseCase) FireCat(catID uint, reason string) (*models.Cat, error) {
+	var firedCat *models.Cat
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		cat, err := uow.Cats().GetForUpdate(catID)
+
+		if cat == nil && err == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+			return apperrors.ErrBadRequestf("There is no cat with such id")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if cat.IsTerminated() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Cat has already been fired"))
+			return apperrors.ErrBadRequestf("Cat has already been fired")
+		}
+
+		onMission, err := uow.Cats().HasActiveMission(catID)
+
+		if err != nil {
+			return err
+		}
+
+		if onMission {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("You cannot fire cat, while it is on mission"))
+			return apperrors.ErrBadRequestf("You cannot fire cat, while it is on mission")
+		}
+
+		firedCat, err = uow.Cats().Terminate(catID, reason)
+
+		if err != nil {
+			return err
+		}
+
+		return uow.Cats().CancelPendingSalaryChanges(catID, "Cat has been fired")
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return firedCat, nil
+}
+
+func (uc *catUseCase) RehireCat(catID uint) (*models.Cat, error) {
 	cat, err := uc.catRepository.Get(catID)
 
 	if cat == nil && err == nil {
 		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
-		return apperrors.ErrBadRequestf("There is no cat with such id")
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
 	}
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = uc.catRepository.Delete(catID)
+	if !cat.IsTerminated() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Cat is currently employed"))
+		return nil, apperrors.ErrBadRequestf("Cat is currently employed")
+	}
 
-	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "foreign_key_violation":
+	return uc.catRepository.Rehire(catID)
+}
+
+func (uc *catUseCase) Update(catID uint, update models.CatUpdate) (*models.Cat, error) {
+	if update.Name == nil && update.YearsOfExperience == nil && update.Breed == nil && update.Salary == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Nothing to update"))
+		return nil, apperrors.ErrBadRequestf("Nothing to update")
+	}
+
+	var updatedCat *models.Cat
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		cat, err := uow.Cats().GetForUpdate(catID)
+
+		if cat == nil && err == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+			return apperrors.ErrBadRequestf("There is no cat with such id")
+		}
 
-				return apperrors.ErrBadRequestf("You cannot fire cat, while it is on mission")
+		if err != nil {
+			return err
+		}
+
+		if update.Salary != nil && cat.IsTerminated() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Salary of a fired cat cannot be changed"))
+			return apperrors.ErrBadRequestf("Salary of a fired cat cannot be changed")
+		}
+
+		if update.Salary != nil {
+			rank, err := uc.getRank(cat.Rank)
+
+			if err != nil {
+				return err
 			}
+
+			if err := uc.checkSalaryBand(rank, *update.Salary); err != nil {
+				return err
+			}
+		}
+
+		updatedCat, err = uow.Cats().Update(catID, update)
+
+		if err != nil {
+			return err
 		}
 
-		return apperrors.ErrDatabase
+		if update.Salary != nil && *update.Salary != cat.Salary {
+			now := time.Now()
+			_, err = uow.Cats().AddSalaryChange(models.SalaryChange{
+				CatID:       catID,
+				OldSalary:   &cat.Salary,
+				NewSalary:   *update.Salary,
+				Reason:      "Profile update",
+				EffectiveAt: now,
+				AppliedAt:   &now,
+			})
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return updatedCat, nil
 
 }
 
-func (uc *catUseCase) UpdateSalary(catID uint, salary float64) (*models.Cat, error) {
-	cat, _ := uc.catRepository.Get(catID)
+func (uc *catUseCase) UpdateSalary(catID uint, salary float64, reason string, effectiveAt *time.Time) (*models.SalaryChange, error) {
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cat.IsTerminated() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Salary of a fired cat cannot be changed"))
+		return nil, apperrors.ErrBadRequestf("Salary of a fired cat cannot be changed")
+	}
+
+	rank, err := uc.getRank(cat.Rank)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkSalaryBand(rank, salary); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	change := models.SalaryChange{
+		CatID:       catID,
+		NewSalary:   salary,
+		Reason:      reason,
+		EffectiveAt: now,
+	}
+
+	if effectiveAt != nil {
+		change.EffectiveAt = *effectiveAt
+	}
+
+	if change.EffectiveAt.After(now) {
+		return uc.catRepository.AddSalaryChange(change)
+	}
+
+	var applied *models.SalaryChange
+
+	err = uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		current, err := uow.Cats().GetForUpdate(catID)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := uow.Cats().Update(catID, models.CatUpdate{Salary: &salary}); err != nil {
+			return err
+		}
+
+		change.OldSalary = &current.Salary
+		change.AppliedAt = &now
+
+		applied, err = uow.Cats().AddSalaryChange(change)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
 
-	if cat == nil {
+	return applied, nil
+}
 
+func (uc *catUseCase) SalaryHistory(catID uint) ([]models.SalaryChange, error) {
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
 		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
 	}
 
-	updatedCat, err := uc.catRepository.Update(catID, salary)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.catRepository.ListSalaryHistory(catID)
+}
+
+// ApplyScheduledSalaryChanges applies future-dated salary changes whose effective date has passed.
+func (uc *catUseCase) ApplyScheduledSalaryChanges() error {
+	now := time.Now()
+
+	dueChanges, err := uc.catRepository.ListDueSalaryChanges(now)
+
+	if err != nil {
+		return err
+	}
 
-	return updatedCat, err
+	// A change that cannot be applied is cancelled with the reason so that it
+	// neither blocks the rest of the batch nor is retried on every run.
+	for _, change := range dueChanges {
+		if err := uc.applySalaryChange(change.ID); err != nil {
+			uc.logger.Warnf("Failed to apply salary change %d for cat %d: %s", change.ID, change.CatID, err.Error())
 
+			if err := uc.cancelSalaryChange(change.ID, "Failed to apply: "+err.Error()); err != nil {
+				uc.logger.Warnf("Failed to cancel salary change %d: %s", change.ID, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// cancelSalaryChange resolves a still pending change as cancelled with note.
+func (uc *catUseCase) cancelSalaryChange(changeID uint, note string) error {
+	return uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		change, err := uow.Cats().LockSalaryChange(changeID)
+
+		if err != nil || change == nil {
+			return err
+		}
+
+		return uow.Cats().ResolveSalaryChange(models.SalaryChangeOutcome{
+			ChangeID: change.ID,
+			Outcome:  models.SalaryChangeCancelled,
+			Note:     note,
+		})
+	})
 }
 
-func (uc *catUseCase) List() ([]models.Cat, error) {
-	list, err := uc.catRepository.List()
+// applySalaryChange applies one pending change and records its outcome in the
+// same transaction. A change already resolved or held by another instance is
+// left alone.
+func (uc *catUseCase) applySalaryChange(changeID uint) error {
+	return uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		change, err := uow.Cats().LockSalaryChange(changeID)
+
+		if err != nil || change == nil {
+			return err
+		}
+
+		cat, err := uow.Cats().GetForUpdate(change.CatID)
+
+		if err != nil {
+			return err
+		}
+
+		if cat.IsTerminated() {
+			uc.logger.Warnf("Cancelling salary change %d: cat %d has been fired", change.ID, cat.ID)
+
+			return uow.Cats().ResolveSalaryChange(models.SalaryChangeOutcome{
+				ChangeID: change.ID,
+				Outcome:  models.SalaryChangeCancelled,
+				Note:     "Cat has been fired",
+			})
+		}
+
+		rank, err := uc.getRank(cat.Rank)
+
+		if err != nil {
+			return err
+		}
+
+		if !rank.AllowsSalary(change.NewSalary) {
+			note := fmt.Sprintf("Salary is outside the %s band of %.2f to %.2f", rank.Name, rank.MinSalary, rank.MaxSalary)
+			uc.logger.Warnf("Cancelling salary change %d for cat %d: %s", change.ID, cat.ID, note)
+
+			return uow.Cats().ResolveSalaryChange(models.SalaryChangeOutcome{
+				ChangeID: change.ID,
+				Outcome:  models.SalaryChangeCancelled,
+				Note:     note,
+			})
+		}
+
+		if _, err := uow.Cats().Update(cat.ID, models.CatUpdate{Salary: &change.NewSalary}); err != nil {
+			return err
+		}
+
+		err = uow.Cats().ResolveSalaryChange(models.SalaryChangeOutcome{
+			ChangeID:  change.ID,
+			Outcome:   models.SalaryChangeApplied,
+			OldSalary: &cat.Salary,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		uc.logger.Infof("Applied scheduled salary change %d for cat %d", change.ID, cat.ID)
+
+		return nil
+	})
+}
+
+func (uc *catUseCase) List(query models.CatListQuery) (*models.CatPage, error) {
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+
+	page, err := uc.catRepository.List(query)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return list, nil
+	return page, nil
 }
 
 func (uc *catUseCase) Get(catID uint) (*models.Cat, error) {
@@ -111,3 +519,263 @@ func (uc *catUseCase) Get(catID uint) (*models.Cat, error) {
 	return cat, nil
 
 }
+
+func (uc *catUseCase) AddLeave(leave models.Leave) (*models.Leave, error) {
+	if leave.EndDate.Before(leave.StartDate) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Leave cannot end before it starts"))
+		return nil, apperrors.ErrBadRequestf("Leave cannot end before it starts")
+	}
+
+	cat, err := uc.catRepository.Get(leave.CatID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	overlapping, err := uc.catRepository.ListOverlappingLeaves(leave.CatID, leave.StartDate, &leave.EndDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(overlapping) > 0 {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Leave overlaps with an existing leave"))
+		return nil, apperrors.ErrBadRequestf("Leave overlaps with an existing leave")
+	}
+
+	return uc.catRepository.AddLeave(leave)
+}
+
+func (uc *catUseCase) ListLeaves(catID uint) ([]models.Leave, error) {
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.catRepository.ListLeaves(catID)
+}
+
+func (uc *catUseCase) CancelLeave(catID, leaveID uint) error {
+	leave, err := uc.catRepository.GetLeave(leaveID)
+
+	if err != nil {
+		return err
+	}
+
+	if leave == nil || leave.CatID != catID {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no leave with such id"))
+		return apperrors.ErrBadRequestf("There is no leave with such id")
+	}
+
+	return uc.catRepository.DeleteLeave(leaveID)
+}
+
+func (uc *catUseCase) Stats(catID uint) (*models.CatStats, error) {
+	list, err := uc.catRepository.ListStats([]uint{catID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	return &list[0], nil
+}
+
+func (uc *catUseCase) BulkStats(catIDs []uint) ([]models.CatStats, error) {
+	if len(catIDs) == 0 {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("No cat ids provided"))
+		return nil, apperrors.ErrBadRequestf("No cat ids provided")
+	}
+
+	if len(catIDs) > MaxBulkStatsCats {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Too many cat ids"))
+		return nil, apperrors.ErrBadRequestf("Too many cat ids")
+	}
+
+	return uc.catRepository.ListStats(catIDs)
+}
+
+// Promote moves the cat to rankCode, or to the next rank up when rankCode is empty.
+// A salary below the new band is raised to the band minimum; one above it is rejected.
+func (uc *catUseCase) Promote(catID uint, rankCode string) (*models.Cat, error) {
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cat.IsTerminated() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Fired cat cannot be promoted"))
+		return nil, apperrors.ErrBadRequestf("Fired cat cannot be promoted")
+	}
+
+	current, err := uc.getRank(cat.Rank)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var target *models.Rank
+
+	if rankCode != "" {
+		target, err = uc.getRank(rankCode)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ranks, err := uc.rankRepository.List()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range ranks {
+			if ranks[i].Position > current.Position {
+				target = &ranks[i]
+				break
+			}
+		}
+
+		if target == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Cat already holds the highest rank"))
+			return nil, apperrors.ErrBadRequestf("Cat already holds the highest rank")
+		}
+	}
+
+	if target.Position <= current.Position {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Promotion must move the cat to a higher rank"))
+		return nil, apperrors.ErrBadRequestf("Promotion must move the cat to a higher rank")
+	}
+
+	if cat.YearsOfExperience < target.MinYearsOfExperience {
+		msg := fmt.Sprintf("%s requires at least %d years of experience", target.Name, target.MinYearsOfExperience)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return nil, apperrors.ErrBadRequestf(msg)
+	}
+
+	stats, err := uc.Stats(catID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.MissionsCompleted < target.MinCompletedMissions {
+		msg := fmt.Sprintf("%s requires at least %d completed missions", target.Name, target.MinCompletedMissions)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return nil, apperrors.ErrBadRequestf(msg)
+	}
+
+	update := models.CatUpdate{Rank: &target.Code}
+
+	var promotedCat *models.Cat
+
+	err = uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		current, err := uow.Cats().GetForUpdate(catID)
+
+		if err != nil {
+			return err
+		}
+
+		if current.Salary > target.MaxSalary {
+			msg := fmt.Sprintf("Salary %.2f is above the %s band maximum of %.2f", current.Salary, target.Name, target.MaxSalary)
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+			return apperrors.ErrBadRequestf(msg)
+		}
+
+		if current.Salary < target.MinSalary {
+			update.Salary = &target.MinSalary
+		}
+
+		promotedCat, err = uow.Cats().Update(catID, update)
+
+		if err != nil {
+			return err
+		}
+
+		if update.Salary != nil {
+			now := time.Now()
+			_, err = uow.Cats().AddSalaryChange(models.SalaryChange{
+				CatID:       catID,
+				OldSalary:   &current.Salary,
+				NewSalary:   *update.Salary,
+				Reason:      "Promotion to " + target.Name,
+				EffectiveAt: now,
+				AppliedAt:   &now,
+			})
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return promotedCat, nil
+}
+
+func (uc *catUseCase) checkHire(cat *models.Cat) error {
+	if cat.Rank == "" {
+		cat.Rank = models.DefaultRank
+	}
+
+	rank, err := uc.getRank(cat.Rank)
+
+	if err != nil {
+		return err
+	}
+
+	if cat.YearsOfExperience < rank.MinYearsOfExperience || rank.MinCompletedMissions > 0 {
+		msg := fmt.Sprintf("New cat does not qualify for rank %s", rank.Name)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return apperrors.ErrBadRequestf(msg)
+	}
+
+	return uc.checkSalaryBand(rank, cat.Salary)
+}
+
+func (uc *catUseCase) getRank(code string) (*models.Rank, error) {
+	rank, err := uc.rankRepository.Get(code)
+
+	if rank == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no rank with such code"))
+		return nil, apperrors.ErrBadRequestf("There is no rank with such code")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rank, nil
+}
+
+func (uc *catUseCase) checkSalaryBand(rank *models.Rank, salary float64) error {
+	if !rank.AllowsSalary(salary) {
+		msg := fmt.Sprintf("Salary for %s must be between %.2f and %.2f", rank.Name, rank.MinSalary, rank.MaxSalary)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return apperrors.ErrBadRequestf(msg)
+	}
+
+	return nil
+}
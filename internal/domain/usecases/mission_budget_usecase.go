@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"fmt"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"time"
+)
+
+// SetBudget sets or replaces the mission's budget. A budget below what was
+// already spent is accepted; it only affects expenses recorded afterwards.
+func (uc *missionUseCase) SetBudget(missionID uint, amount float64, policy string) (*models.Mission, error) {
+	if policy == "" {
+		policy = models.DefaultBudgetPolicy
+	}
+
+	if !models.IsWholeCents(amount) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Budget must not contain fractions of a cent"))
+		return nil, apperrors.ErrBadRequestf("Budget must not contain fractions of a cent")
+	}
+
+	var mission *models.Mission
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		current, err := uow.Missions().GetByID(missionID)
+		if err == nil && current == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
+
+		if current.IsClosed() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission budget cannot be changed"))
+			return apperrors.ErrBadRequestf("Closed mission budget cannot be changed")
+		}
+
+		budget, err := uow.Missions().SetBudget(models.MissionBudget{
+			MissionID: missionID,
+			Amount:    amount,
+			Policy:    policy,
+		})
+		if err != nil {
+			return err
+		}
+
+		current.Budget = budget
+		mission = current
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mission, nil
+}
+
+// AddExpense records an expense against the mission. When it would exceed the
+// budget, a block policy rejects it and a warn policy records it flagged.
+func (uc *missionUseCase) AddExpense(expense models.Expense) (*models.ExpenseResult, error) {
+	if !models.IsWholeCents(expense.Amount) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Expense must not contain fractions of a cent"))
+		return nil, apperrors.ErrBadRequestf("Expense must not contain fractions of a cent")
+	}
+
+	if expense.SpentOn.After(time.Now()) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Expense date cannot be in the future"))
+		return nil, apperrors.ErrBadRequestf("Expense date cannot be in the future")
+	}
+
+	var result models.ExpenseResult
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		mission, err := uow.Missions().GetByID(expense.MissionID)
+		if err == nil && mission == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
+
+		if mission.IsClosed() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Expenses cannot be recorded against closed missions"))
+			return apperrors.ErrBadRequestf("Expenses cannot be recorded against closed missions")
+		}
+
+		budget, err := uow.Missions().LockBudget(expense.MissionID)
+		if err != nil {
+			return err
+		}
+
+		if budget != nil && !budget.Covers(expense.Amount) {
+			msg := fmt.Sprintf("Expense of %.2f exceeds the remaining mission budget of %.2f", expense.Amount, budget.Remaining())
+
+			if budget.Policy == models.BudgetPolicyBlock {
+				uc.logger.Warnf(apperrors.ErrConflictMsg(msg))
+				return apperrors.ErrConflictf(msg)
+			}
+
+			uc.logger.Warnf("Mission %d over budget: %s", expense.MissionID, msg)
+			result.OverBudget = true
+		}
+
+		created, err := uow.Missions().AddExpense(expense)
+		if err != nil {
+			return err
+		}
+
+		result.Expense = *created
+
+		if budget != nil {
+			budget.Spent = float64(models.Cents(budget.Spent)+models.Cents(created.Amount)) / 100
+			result.Budget = budget
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (uc *missionUseCase) ListExpenses(missionID uint) ([]models.Expense, error) {
+	if _, err := uc.Get(missionID); err != nil {
+		return nil, err
+	}
+
+	return uc.missionRepository.ListExpenses(missionID)
+}
@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"fmt"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+)
+
+type (
+	MissionTemplateRepositoryInterface interface {
+		Add(template models.MissionTemplate) (*models.MissionTemplate, error)
+		Get(id uint) (*models.MissionTemplate, error)
+		List() ([]models.MissionTemplate, error)
+		Update(template models.MissionTemplate) (*models.MissionTemplate, error)
+		Delete(id uint) error
+	}
+
+	// MissionCreatorInterface is the mission use case, so that missions made
+	// from templates pass the same validation as any other mission.
+	MissionCreatorInterface interface {
+		Create(mission models.Mission) (*models.Mission, error)
+	}
+
+	missionTemplateUseCase struct {
+		logger             logger.Logger
+		templateRepository MissionTemplateRepositoryInterface
+		missionTypes       MissionTypeRepositoryInterface
+		missionCreator     MissionCreatorInterface
+	}
+)
+
+func NewMissionTemplateUseCase(customLogger logger.Logger, templateRepo MissionTemplateRepositoryInterface, missionTypeRepo MissionTypeRepositoryInterface, missionCreator MissionCreatorInterface) *missionTemplateUseCase {
+	return &missionTemplateUseCase{
+		logger:             customLogger,
+		templateRepository: templateRepo,
+		missionTypes:       missionTypeRepo,
+		missionCreator:     missionCreator,
+	}
+}
+
+func (uc *missionTemplateUseCase) Create(template models.MissionTemplate) (*models.MissionTemplate, error) {
+	if template.MissionType == "" {
+		template.MissionType = models.DefaultMissionType
+	}
+
+	if err := uc.checkTemplate(&template); err != nil {
+		return nil, err
+	}
+
+	return uc.templateRepository.Add(template)
+}
+
+func (uc *missionTemplateUseCase) Get(id uint) (*models.MissionTemplate, error) {
+	template, err := uc.templateRepository.Get(id)
+
+	if template == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission template with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no mission template with such id")
+	}
+
+	return template, err
+}
+
+func (uc *missionTemplateUseCase) List() ([]models.MissionTemplate, error) {
+	return uc.templateRepository.List()
+}
+
+func (uc *missionTemplateUseCase) Update(template models.MissionTemplate) (*models.MissionTemplate, error) {
+	if template.MissionType == "" {
+		template.MissionType = models.DefaultMissionType
+	}
+
+	if err := uc.checkTemplate(&template); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.templateRepository.Update(template)
+
+	if updated == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission template with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no mission template with such id")
+	}
+
+	return updated, err
+}
+
+func (uc *missionTemplateUseCase) Delete(id uint) error {
+	if _, err := uc.Get(id); err != nil {
+		return err
+	}
+
+	return uc.templateRepository.Delete(id)
+}
+
+// Instantiate creates a mission from the template. The caller may override the
+// rendered name and assign a cat and deadline straight away.
+func (uc *missionTemplateUseCase) Instantiate(id uint, instance models.TemplateInstance) (*models.Mission, error) {
+	template, err := uc.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	mission := models.Mission{
+		Name:       instance.Name,
+		Type:       template.MissionType,
+		CatId:      instance.CatID,
+		DueAt:      instance.DueAt,
+		TargetList: make([]models.Target, 0, len(template.Targets)),
+	}
+
+	if mission.Name == "" {
+		mission.Name = template.MissionName()
+	}
+
+	for _, stub := range template.Targets {
+		target := models.Target{
+			Name:    stub.Name,
+			Country: stub.Country,
+			Notes:   stub.Notes,
+		}
+
+		if target.Notes == "" {
+			target.Notes = template.Notes
+		}
+
+		mission.TargetList = append(mission.TargetList, target)
+	}
+
+	return uc.missionCreator.Create(mission)
+}
+
+// checkTemplate rejects templates that could never produce a valid mission: a
+// name pattern that does not render to a valid mission name, or a target count
+// the mission type does not allow.
+func (uc *missionTemplateUseCase) checkTemplate(template *models.MissionTemplate) error {
+	if !models.IsValidMissionName(template.MissionName()) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Name pattern must render to a name of letters only"))
+		return apperrors.ErrBadRequestf("Name pattern must render to a name of letters only")
+	}
+
+	policy, err := uc.missionTypes.Get(template.MissionType)
+
+	if policy == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission type with such code"))
+		return apperrors.ErrBadRequestf("There is no mission type with such code")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !policy.AllowsTargetCount(len(template.Targets)) {
+		msg := fmt.Sprintf("Target limit exceeded: %s missions need between %d and %d targets", policy.Name, policy.MinTargets, policy.MaxTargets)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return apperrors.ErrBadRequestf(msg)
+	}
+
+	return nil
+}
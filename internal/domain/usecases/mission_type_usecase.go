@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+)
+
+type (
+	MissionTypeRepositoryInterface interface {
+		Add(missionType models.MissionType) (*models.MissionType, error)
+		List() ([]models.MissionType, error)
+		Get(code string) (*models.MissionType, error)
+		Update(code string, update models.MissionTypeUpdate) (*models.MissionType, error)
+	}
+
+	missionTypeUseCase struct {
+		logger                logger.Logger
+		missionTypeRepository MissionTypeRepositoryInterface
+	}
+)
+
+func NewMissionTypeUseCase(customLogger logger.Logger, missionTypeRepo MissionTypeRepositoryInterface) *missionTypeUseCase {
+	return &missionTypeUseCase{
+		logger:                customLogger,
+		missionTypeRepository: missionTypeRepo,
+	}
+}
+
+func (uc *missionTypeUseCase) List() ([]models.MissionType, error) {
+	return uc.missionTypeRepository.List()
+}
+
+func (uc *missionTypeUseCase) Create(missionType models.MissionType) (*models.MissionType, error) {
+	if err := uc.checkTargetLimits(missionType.MinTargets, missionType.MaxTargets); err != nil {
+		return nil, err
+	}
+
+	return uc.missionTypeRepository.Add(missionType)
+}
+
+func (uc *missionTypeUseCase) Update(code string, update models.MissionTypeUpdate) (*models.MissionType, error) {
+	missionType, err := uc.missionTypeRepository.Get(code)
+
+	if missionType == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission type with such code"))
+		return nil, apperrors.ErrBadRequestf("There is no mission type with such code")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	minTargets, maxTargets := missionType.MinTargets, missionType.MaxTargets
+	if update.MinTargets != nil {
+		minTargets = *update.MinTargets
+	}
+	if update.MaxTargets != nil {
+		maxTargets = *update.MaxTargets
+	}
+
+	if err := uc.checkTargetLimits(minTargets, maxTargets); err != nil {
+		return nil, err
+	}
+
+	if update.Name == nil && update.MinTargets == nil && update.MaxTargets == nil && update.AllowDeleteCompletedTargets == nil && update.AllowDeleteAssigned == nil {
+		return missionType, nil
+	}
+
+	return uc.missionTypeRepository.Update(code, update)
+}
+
+func (uc *missionTypeUseCase) checkTargetLimits(minTargets, maxTargets uint) error {
+	if minTargets < 1 {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("A mission needs at least one target"))
+		return apperrors.ErrBadRequestf("A mission needs at least one target")
+	}
+
+	if minTargets > maxTargets {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Minimum targets cannot exceed maximum targets"))
+		return apperrors.ErrBadRequestf("Minimum targets cannot exceed maximum targets")
+	}
+
+	return nil
+}
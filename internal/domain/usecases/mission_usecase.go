@@ -1,131 +1,442 @@
 package usecases
 
 import (
+	"fmt"
 	"spyCatAgency/internal/domain/models"
 	"spyCatAgency/internal/infrastructure/apperrors"
 	"spyCatAgency/internal/infrastructure/logger"
+	"time"
 )
 
+// SystemActor is recorded for transitions the agency makes on its own, such as
+// completing a mission once its last target is done.
+const SystemActor = "system"
+
 type (
 	MissionRepositoryInterface interface {
 		Add(mission models.Mission) (*models.Mission, error)
 		AssignToCat(missionId, catId uint) error
+		UnassignCat(missionId uint) error
+		AddMember(member models.MissionMember) (*models.MissionMember, error)
+		RemoveMember(missionID, catID uint) error
+		AddHandover(handover models.Handover) (*models.Handover, error)
+		ListHandovers(missionID uint) ([]models.Handover, error)
 		GetByID(id uint) (*models.Mission, error)
-		GetByCatID(catID uint) (*models.Mission, error)
+		GetForUpdate(id uint) (*models.Mission, error)
+		GetActiveByCatID(catID uint) (*models.Mission, error)
+		ListByCatID(query models.CatMissionsQuery) ([]models.Mission, error)
 		Delete(id uint) error
-		List() ([]models.Mission, error)
-		Update(id uint, completed bool) error
+		List(query models.MissionListQuery) (*models.MissionPage, error)
+		SetDueAt(id uint, dueAt time.Time) error
+		FlagOverdue(now time.Time) ([]models.Mission, error)
+		SetState(transition models.MissionTransition) (*models.MissionTransition, error)
+		ListTransitions(missionID uint) ([]models.MissionTransition, error)
 		GetTarget(id uint) (*models.Target, error)
 		DeleteTarget(id uint) error
 		AddTarget(missionId uint, target models.Target) (*models.Target, error)
-		CompleteTarget(id uint) (*models.Target, error)
-		UpdateTargetNotes(id uint, notes string) (*models.Target, error)
+		CompleteTarget(id uint, completedBy *uint) (*models.Target, error)
+		UpdateTarget(id uint, update models.TargetUpdate) (*models.Target, error)
+		SetBudget(budget models.MissionBudget) (*models.MissionBudget, error)
+		LockBudget(missionID uint) (*models.MissionBudget, error)
+		AddExpense(expense models.Expense) (*models.Expense, error)
+		ListExpenses(missionID uint) ([]models.Expense, error)
 	}
 
 	missionUseCase struct {
 		logger            logger.Logger
 		missionRepository MissionRepositoryInterface
 		catRepository     CatRepositoryInterface
+		skillRepository   SkillRepositoryInterface
+		missionTypes      MissionTypeRepositoryInterface
+		txManager         TransactionManagerInterface
+
+		// leaveHorizon is how far ahead leaves are checked for missions
+		// without a deadline.
+		leaveHorizon time.Duration
+	}
+
+	// missionChange describes what a request is about to do to a mission so
+	// that enforcePolicy can judge it against the mission type.
+	missionChange struct {
+		targetCount   int
+		addedTarget   bool
+		deletedTarget *models.Target
+		deleteMission bool
 	}
 )
 
-func NewMissionUseCase(customLogger logger.Logger, missionRepo MissionRepositoryInterface, catRepo CatRepositoryInterface) *missionUseCase {
+func NewMissionUseCase(customLogger logger.Logger, missionRepo MissionRepositoryInterface, catRepo CatRepositoryInterface, skillRepo SkillRepositoryInterface, missionTypeRepo MissionTypeRepositoryInterface, txManager TransactionManagerInterface, leaveHorizon time.Duration) *missionUseCase {
 	return &missionUseCase{
 		logger:            customLogger,
 		missionRepository: missionRepo,
 		catRepository:     catRepo,
+		skillRepository:   skillRepo,
+		missionTypes:      missionTypeRepo,
+		txManager:         txManager,
+		leaveHorizon:      leaveHorizon,
 	}
 }
 
 func (uc *missionUseCase) Create(mission models.Mission) (*models.Mission, error) {
 
-	if len(mission.TargetList) > 3 || len(mission.TargetList) < 1 {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Target limit exceeded"))
-		return nil, apperrors.ErrBadRequestf("Target limit exceeded")
+	if !models.IsValidMissionName(mission.Name) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Mission name must contain only letters"))
+		return nil, apperrors.ErrBadRequestf("Mission name must contain only letters")
+	}
+
+	if mission.Type == "" {
+		mission.Type = models.DefaultMissionType
+	}
+
+	if err := uc.enforcePolicy(&mission, missionChange{targetCount: len(mission.TargetList)}); err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkDeadline(mission.DueAt, nil); err != nil {
+		return nil, err
 	}
 
-	if mission.CatId != nil {
+	for _, target := range mission.TargetList {
+		if err := uc.checkDeadline(target.DueAt, mission.DueAt); err != nil {
+			return nil, err
+		}
+	}
 
-		cat, err := uc.catRepository.Get(*mission.CatId)
+	required := make(map[uint]bool, len(mission.RequiredSkills))
 
-		if cat == nil && err == nil {
-			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
-			return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	for i, requirement := range mission.RequiredSkills {
+		if required[requirement.SkillID] {
+			msg := fmt.Sprintf("Skill %d is required more than once", requirement.SkillID)
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+			return nil, apperrors.ErrBadRequestf(msg)
 		}
+		required[requirement.SkillID] = true
 
-		catMission, err := uc.missionRepository.GetByCatID(*mission.CatId)
+		skill, err := uc.skillRepository.Get(requirement.SkillID)
+
+		if skill == nil && err == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no skill with such id"))
+			return nil, apperrors.ErrBadRequestf("There is no skill with such id")
+		}
 
 		if err != nil {
 			return nil, err
 		}
 
-		if catMission != nil {
-			uc.logger.Warnf(apperrors.ErrBadRequestMsg("This cat has already been assigned a mission"))
-			return nil, apperrors.ErrBadRequestf("This cat has already been assigned a mission")
+		if requirement.MinLevel < models.MinSkillLevel || requirement.MinLevel > models.MaxSkillLevel {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Invalid required skill level"))
+			return nil, apperrors.ErrBadRequestf("Invalid required skill level")
+		}
+
+		mission.RequiredSkills[i].SkillName = skill.Name
+	}
+
+	var createdMission *models.Mission
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		if mission.CatId != nil {
+			if err := uc.checkAssignable(uow, *mission.CatId, &mission); err != nil {
+				return err
+			}
+		}
+
+		created, err := uow.Missions().Add(mission)
+		if err != nil {
+			return err
+		}
+
+		if mission.CatId != nil {
+			if err := uow.Missions().AssignToCat(created.ID, *mission.CatId); err != nil {
+				return err
+			}
+
+			if _, err := uow.Missions().SetState(models.MissionTransition{
+				MissionID: created.ID,
+				FromState: models.MissionStateDraft,
+				ToState:   models.MissionStateAssigned,
+				Actor:     SystemActor,
+				Reason:    "Assigned on creation",
+			}); err != nil {
+				return err
+			}
+
+			created, err = uow.Missions().GetByID(created.ID)
+			if err != nil {
+				return err
+			}
 		}
+
+		createdMission = created
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	createdMission, err := uc.missionRepository.Add(mission)
+	return createdMission, nil
+}
+
+// Clone creates a new mission from the source mission's name, targets and skill
+// requirements. Progress, deadlines and the assigned cat are not carried over.
+func (uc *missionUseCase) Clone(id uint, clone models.MissionClone) (*models.Mission, error) {
+	source, err := uc.Get(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if mission.CatId != nil {
+	mission := models.Mission{
+		Name:           source.Name,
+		Type:           source.Type,
+		CatId:          clone.CatID,
+		ClonedFromID:   &source.ID,
+		TargetList:     make([]models.Target, 0, len(source.TargetList)),
+		RequiredSkills: make([]models.SkillRequirement, 0, len(source.RequiredSkills)),
+	}
 
-		err = uc.missionRepository.AssignToCat(createdMission.ID, *mission.CatId)
-		if err != nil {
-			return nil, err
+	if clone.Name != "" {
+		mission.Name = clone.Name
+	}
+
+	targets := source.TargetList
+	if len(clone.TargetIDs) > 0 {
+		byID := make(map[uint]models.Target, len(source.TargetList))
+		for _, target := range source.TargetList {
+			byID[target.ID] = target
 		}
 
-		createdMission.CatId = mission.CatId
+		targets = make([]models.Target, 0, len(clone.TargetIDs))
+		for _, targetID := range clone.TargetIDs {
+			target, ok := byID[targetID]
+			if !ok {
+				msg := fmt.Sprintf("Target %d does not belong to this mission", targetID)
+				uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+				return nil, apperrors.ErrBadRequestf(msg)
+			}
+			targets = append(targets, target)
+		}
 	}
 
-	return createdMission, nil
+	for _, target := range targets {
+		mission.TargetList = append(mission.TargetList, models.Target{
+			Name:    target.Name,
+			Country: target.Country,
+			Notes:   target.Notes,
+		})
+	}
+
+	for _, requirement := range source.RequiredSkills {
+		mission.RequiredSkills = append(mission.RequiredSkills, models.SkillRequirement{
+			SkillID:  requirement.SkillID,
+			MinLevel: requirement.MinLevel,
+		})
+	}
+
+	return uc.Create(mission)
 }
 
 func (uc *missionUseCase) Assign(missionId, catID uint) (*models.Mission, error) {
-	mission, err := uc.missionRepository.GetByID(missionId)
-	if err == nil && mission == nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
-		return nil, apperrors.ErrBadRequestf("There is no mission with such id")
+	var mission *models.Mission
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		// The lock keeps a concurrent assign or handover from passing the same
+		// unassigned check.
+		current, err := uow.Missions().GetForUpdate(missionId)
+		if err == nil && current == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
 
-	} else if err != nil {
+		if current.CatId != nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Already assigned"))
+			return apperrors.ErrBadRequestf("Already assigned")
+		}
+
+		if current.State != models.MissionStateDraft {
+			msg := fmt.Sprintf("Mission in state %s cannot be assigned", current.State)
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+			return apperrors.ErrBadRequestf(msg)
+		}
+
+		if err := uc.checkAssignable(uow, catID, current); err != nil {
+			return err
+		}
+
+		if err := uc.checkTeam(uow, current, catID); err != nil {
+			return err
+		}
+
+		if err := uow.Missions().AssignToCat(missionId, catID); err != nil {
+			return err
+		}
+
+		if _, err := uow.Missions().SetState(models.MissionTransition{
+			MissionID: missionId,
+			FromState: models.MissionStateDraft,
+			ToState:   models.MissionStateAssigned,
+			Actor:     SystemActor,
+			Reason:    "Cat assigned",
+		}); err != nil {
+			return err
+		}
+
+		mission, err = uow.Missions().GetByID(missionId)
+		return err
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
-	if mission.CatId != nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Already assigned"))
-		return nil, apperrors.ErrBadRequestf("Already assigned")
+	return mission, nil
+}
+
+// Unassign takes the cat off the mission and returns the mission to draft so it
+// can be assigned again.
+func (uc *missionUseCase) Unassign(missionID uint, reason, actor string) (*models.Mission, error) {
+	if actor == "" {
+		actor = SystemActor
 	}
 
-	cat, err := uc.catRepository.Get(catID)
+	var mission *models.Mission
 
-	if cat == nil && err == nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
-		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
-	}
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		current, err := uc.getAssignedMission(uow, missionID)
+		if err != nil {
+			return err
+		}
 
-	catMission, err := uc.missionRepository.GetByCatID(catID)
+		if _, err := uow.Missions().AddHandover(models.Handover{
+			MissionID:      missionID,
+			FromCatID:      *current.CatId,
+			FromAssignedAt: current.AssignedAt,
+			Reason:         reason,
+			Actor:          actor,
+		}); err != nil {
+			return err
+		}
 
-	uc.logger.Warnf("Cat mission %v", catMission)
+		if err := uow.Missions().UnassignCat(missionID); err != nil {
+			return err
+		}
 
-	if err != nil {
+		if _, err := uow.Missions().SetState(models.MissionTransition{
+			MissionID: missionID,
+			FromState: current.State,
+			ToState:   models.MissionStateDraft,
+			Actor:     actor,
+			Reason:    reason,
+		}); err != nil {
+			return err
+		}
 
+		mission, err = uow.Missions().GetByID(missionID)
+		return err
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
-	if catMission != nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("This cat has already been assigned a mission"))
-		return nil, apperrors.ErrBadRequestf("This cat has already been assigned a mission")
+	return mission, nil
+}
+
+// Reassign hands an active mission over to another cat without changing its state.
+func (uc *missionUseCase) Reassign(missionID, catID uint, reason, actor string) (*models.Mission, error) {
+	if actor == "" {
+		actor = SystemActor
 	}
 
-	err = uc.missionRepository.AssignToCat(missionId, catID)
+	var mission *models.Mission
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		current, err := uc.getAssignedMission(uow, missionID)
+		if err != nil {
+			return err
+		}
+
+		if *current.CatId == catID {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Cat is already assigned to this mission"))
+			return apperrors.ErrBadRequestf("Cat is already assigned to this mission")
+		}
+
+		if err := uc.checkAssignable(uow, catID, current); err != nil {
+			return err
+		}
+
+		if _, err := uow.Missions().AddHandover(models.Handover{
+			MissionID:      missionID,
+			FromCatID:      *current.CatId,
+			ToCatID:        &catID,
+			FromAssignedAt: current.AssignedAt,
+			Reason:         reason,
+			Actor:          actor,
+		}); err != nil {
+			return err
+		}
+
+		if err := uow.Missions().AssignToCat(missionID, catID); err != nil {
+			return err
+		}
+
+		mission, err = uow.Missions().GetByID(missionID)
+		return err
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	mission, err = uc.missionRepository.GetByID(missionId)
+	return mission, nil
+}
+
+// AddMember puts the cat on the mission team. Adding a lead is the same as
+// assigning the mission, so a mission that already has one has to be reassigned.
+func (uc *missionUseCase) AddMember(missionID, catID uint, role string) (*models.Mission, error) {
+	if role == models.MemberRoleLead {
+		return uc.Assign(missionID, catID)
+	}
+
+	var mission *models.Mission
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		current, err := uow.Missions().GetByID(missionID)
+		if err == nil && current == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
+
+		if current.IsClosed() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission team cannot be changed"))
+			return apperrors.ErrBadRequestf("Closed mission team cannot be changed")
+		}
+
+		if current.Member(catID) != nil {
+			uc.logger.Warnf(apperrors.ErrConflictMsg("This cat is already on the mission team"))
+			return apperrors.ErrConflictf("This cat is already on the mission team")
+		}
+
+		if err := uc.checkTeamAvailability(uow, catID, current); err != nil {
+			return err
+		}
+
+		if _, err := uow.Missions().AddMember(models.MissionMember{
+			MissionID: missionID,
+			CatID:     catID,
+			Role:      role,
+		}); err != nil {
+			return err
+		}
+
+		mission, err = uow.Missions().GetByID(missionID)
+		return err
+	})
 
 	if err != nil {
 		return nil, err
@@ -134,9 +445,51 @@ func (uc *missionUseCase) Assign(missionId, catID uint) (*models.Mission, error)
 	return mission, nil
 }
 
-func (uc *missionUseCase) Get(id uint) (*models.Mission, error) {
-	mission, err := uc.missionRepository.GetByID(id)
+// RemoveMember takes a support or observer cat off the team. The lead leaves
+// through unassign or reassign so that a handover is recorded.
+func (uc *missionUseCase) RemoveMember(missionID, catID uint) error {
+	return uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		mission, err := uow.Missions().GetByID(missionID)
+		if err == nil && mission == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
+
+		if mission.IsClosed() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission team cannot be changed"))
+			return apperrors.ErrBadRequestf("Closed mission team cannot be changed")
+		}
+
+		member := mission.Member(catID)
+		if member == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("This cat is not on the mission team"))
+			return apperrors.ErrBadRequestf("This cat is not on the mission team")
+		}
+
+		if member.Role == models.MemberRoleLead {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Mission lead can only be removed by unassigning or reassigning the mission"))
+			return apperrors.ErrBadRequestf("Mission lead can only be removed by unassigning or reassigning the mission")
+		}
+
+		return uow.Missions().RemoveMember(missionID, catID)
+	})
+}
+
+func (uc *missionUseCase) ListHandovers(missionID uint) ([]models.Handover, error) {
+	if _, err := uc.Get(missionID); err != nil {
+		return nil, err
+	}
+
+	return uc.missionRepository.ListHandovers(missionID)
+}
 
+// getAssignedMission locks the mission for the rest of the unit of work so that
+// concurrent handovers cannot both act on the same lead.
+func (uc *missionUseCase) getAssignedMission(uow UnitOfWork, missionID uint) (*models.Mission, error) {
+	mission, err := uow.Missions().GetForUpdate(missionID)
 	if err == nil && mission == nil {
 		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
 		return nil, apperrors.ErrBadRequestf("There is no mission with such id")
@@ -144,46 +497,134 @@ func (uc *missionUseCase) Get(id uint) (*models.Mission, error) {
 	} else if err != nil {
 		return nil, err
 	}
+
+	if mission.IsClosed() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission cannot be handed over"))
+		return nil, apperrors.ErrBadRequestf("Closed mission cannot be handed over")
+	}
+
+	if mission.CatId == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Mission is not assigned"))
+		return nil, apperrors.ErrBadRequestf("Mission is not assigned")
+	}
+
 	return mission, nil
 }
 
-func (uc *missionUseCase) Delete(id uint) error {
+// checkAssignable verifies, inside the caller's unit of work, that the cat can
+// lead the mission: the team availability rules plus the mission's skill
+// requirements, which only the lead has to meet.
+func (uc *missionUseCase) checkAssignable(uow UnitOfWork, catID uint, mission *models.Mission) error {
+	if err := uc.checkTeamAvailability(uow, catID, mission); err != nil {
+		return err
+	}
 
-	mission, err := uc.missionRepository.GetByID(id)
-	if err == nil && mission == nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
-		return apperrors.ErrBadRequestf("There is no mission with such id")
+	return uc.checkSkillRequirements(uow, catID, mission.RequiredSkills)
+}
 
-	} else if err != nil {
+// checkTeamAvailability is applied to every cat joining a mission team in any role.
+func (uc *missionUseCase) checkTeamAvailability(uow UnitOfWork, catID uint, mission *models.Mission) error {
+	cat, err := uow.Cats().Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
 		return err
 	}
 
-	if mission.CatId != nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Assigned mission cannot be deleted"))
-		return apperrors.ErrBadRequestf("Assigned mission cannot be deleted")
+	if cat.IsTerminated() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Fired cat cannot be assigned a mission"))
+		return apperrors.ErrBadRequestf("Fired cat cannot be assigned a mission")
+	}
+
+	if err := uc.checkAvailability(uow, catID, mission.DueAt); err != nil {
+		return err
 	}
 
-	err = uc.missionRepository.Delete(id)
+	catMission, err := uow.Missions().GetActiveByCatID(catID)
 
 	if err != nil {
 		return err
 	}
 
+	// A member of this very mission may still move to another role on it.
+	if catMission != nil && (mission.ID == 0 || catMission.ID != mission.ID) {
+		uc.logger.Warnf(apperrors.ErrConflictMsg("This cat is already on an active mission"))
+		return apperrors.ErrConflictf("This cat is already on an active mission")
+	}
+
 	return nil
 }
 
-func (uc *missionUseCase) ListMissions() ([]models.Mission, error) {
-	list, err := uc.missionRepository.List()
+// checkTeam re-applies the availability rules to the members already on the
+// mission, whose situation may have changed since they joined a draft. The cat
+// with id skip has been checked by the caller.
+func (uc *missionUseCase) checkTeam(uow UnitOfWork, mission *models.Mission, skip uint) error {
+	for _, member := range mission.Team {
+		if member.CatID == skip {
+			continue
+		}
+
+		if err := uc.checkTeamAvailability(uow, member.CatID, mission); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *missionUseCase) checkSkillRequirements(uow UnitOfWork, catID uint, requirements []models.SkillRequirement) error {
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	catSkills, err := uow.Skills().ListCatSkills(catID)
 
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if unmet := unmetRequirements(requirements, catSkills); len(unmet) > 0 {
+		err := skillRequirementsError(unmet)
+		uc.logger.Warnf(err.Error())
+		return err
 	}
 
-	return list, nil
+	return nil
 }
 
-func (uc *missionUseCase) Update(id uint, completed bool) (*models.Mission, error) {
+// checkAvailability refuses cats whose leave overlaps the mission's active window,
+// which starts now and ends at the deadline, or after the leave horizon if there
+// is none.
+func (uc *missionUseCase) checkAvailability(uow UnitOfWork, catID uint, dueAt *time.Time) error {
+	now := time.Now()
+
+	until := now.Add(uc.leaveHorizon)
+	if dueAt != nil {
+		until = *dueAt
+	}
+
+	leaves, err := uow.Cats().ListOverlappingLeaves(catID, now, &until)
+
+	if err != nil {
+		return err
+	}
+
+	if len(leaves) > 0 {
+		msg := fmt.Sprintf("Cat is on leave from %s to %s", leaves[0].StartDate.Format(time.DateOnly), leaves[0].EndDate.Format(time.DateOnly))
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return apperrors.ErrBadRequestf(msg)
+	}
+
+	return nil
+}
+
+func (uc *missionUseCase) Get(id uint) (*models.Mission, error) {
 	mission, err := uc.missionRepository.GetByID(id)
+
 	if err == nil && mission == nil {
 		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
 		return nil, apperrors.ErrBadRequestf("There is no mission with such id")
@@ -191,114 +632,300 @@ func (uc *missionUseCase) Update(id uint, completed bool) (*models.Mission, erro
 	} else if err != nil {
 		return nil, err
 	}
+	return mission, nil
+}
+
+func (uc *missionUseCase) Delete(id uint) error {
+	return uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		// The lock keeps an assignment from slipping in between the policy
+		// check and the delete.
+		mission, err := uow.Missions().GetForUpdate(id)
+		if err == nil && mission == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
+
+		if err := uc.enforcePolicy(mission, missionChange{targetCount: len(mission.TargetList), deleteMission: true}); err != nil {
+			return err
+		}
+
+		return uow.Missions().Delete(id)
+	})
+}
+
+func (uc *missionUseCase) ListMissions(query models.MissionListQuery) (*models.MissionPage, error) {
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
 
-	if mission.IsCompleted {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed mission cannot be updated"))
-		return nil, apperrors.ErrBadRequestf("Completed mission cannot be updated")
+	if query.CreatedFrom != nil && query.CreatedTo != nil && query.CreatedFrom.After(*query.CreatedTo) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Date range start must not be after its end"))
+		return nil, apperrors.ErrBadRequestf("Date range start must not be after its end")
 	}
 
-	err = uc.missionRepository.Update(id, completed)
+	page, err := uc.missionRepository.List(query)
 
 	if err != nil {
 		return nil, err
 	}
 
-	mission, err = uc.missionRepository.GetByID(id)
+	return page, nil
+}
+
+func (uc *missionUseCase) ListCatMissions(query models.CatMissionsQuery) ([]models.Mission, error) {
+	cat, err := uc.catRepository.Get(query.CatID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	return mission, nil
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Date range start must not be after its end"))
+		return nil, apperrors.ErrBadRequestf("Date range start must not be after its end")
+	}
+
+	return uc.missionRepository.ListByCatID(query)
 }
 
-func (uc *missionUseCase) GetTarget(id uint) (*models.Target, error) {
-	target, err := uc.missionRepository.GetTarget(id)
+// Update keeps the is_completed patch working: completing a mission is a
+// transition to the completed state on behalf of actor, anything else leaves
+// the state alone. Clients that predate actor get the system actor.
+func (uc *missionUseCase) Update(id uint, completed bool, actor string) (*models.Mission, error) {
+	if actor == "" {
+		actor = SystemActor
+	}
 
-	if err == nil && target == nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no target with such id"))
-		return nil, apperrors.ErrBadRequestf("There is no target with such id")
-	} else if err != nil {
+	if completed {
+		return uc.Transition(id, models.MissionStateCompleted, actor, "")
+	}
+
+	mission, err := uc.Get(id)
+
+	if err != nil {
 		return nil, err
 	}
 
-	return target, nil
+	if mission.IsClosed() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission cannot be updated"))
+		return nil, apperrors.ErrBadRequestf("Closed mission cannot be updated")
+	}
+
+	return mission, nil
 }
 
-func (uc *missionUseCase) DeleteTarget(id uint) error {
+func (uc *missionUseCase) SetDueAt(id uint, dueAt time.Time) (*models.Mission, error) {
+	mission, err := uc.Get(id)
 
-	target, err := uc.missionRepository.GetTarget(id)
+	if err != nil {
+		return nil, err
+	}
 
-	if err == nil && target == nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no target with such id"))
-		return apperrors.ErrBadRequestf("There is no target with such id")
-	} else if err != nil {
-		return err
+	if mission.IsClosed() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission cannot be updated"))
+		return nil, apperrors.ErrBadRequestf("Closed mission cannot be updated")
 	}
-	if target.IsCompleted {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed target cannot be deleted"))
-		return apperrors.ErrBadRequestf("Completed target cannot be deleted")
+
+	if err := uc.checkDeadline(&dueAt, nil); err != nil {
+		return nil, err
 	}
 
-	mission, err := uc.missionRepository.GetByID(target.MissionID)
+	for _, target := range mission.TargetList {
+		if target.DueAt != nil && target.DueAt.After(dueAt) {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Target deadline must not be later than the mission deadline"))
+			return nil, apperrors.ErrBadRequestf("Target deadline must not be later than the mission deadline")
+		}
+	}
+
+	if err := uc.missionRepository.SetDueAt(id, dueAt); err != nil {
+		return nil, err
+	}
+
+	return uc.missionRepository.GetByID(id)
+}
+
+// FlagOverdueMissions is run by the scheduler and reports every mission that
+// went past its deadline since the previous run.
+func (uc *missionUseCase) FlagOverdueMissions() error {
+	missions, err := uc.missionRepository.FlagOverdue(time.Now())
 
 	if err != nil {
 		return err
 	}
 
-	if mission.IsCompleted {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Targets cannot be deleted from completed missions"))
-		return apperrors.ErrBadRequestf("Targets cannot be deleted from completed missions")
+	for _, mission := range missions {
+		catID := "nobody"
+		if mission.CatId != nil {
+			catID = fmt.Sprintf("cat %d", *mission.CatId)
+		}
+		uc.logger.Warnf("Mission %d (%s) assigned to %s is overdue since %s", mission.ID, mission.Name, catID, mission.DueAt.Format(time.RFC3339))
 	}
 
-	if len(mission.TargetList) == 1 {
+	return nil
+}
+
+func (uc *missionUseCase) Transition(id uint, state, actor, reason string) (*models.Mission, error) {
+	var mission *models.Mission
 
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("You cannot delete last targer of the mission"))
-		return apperrors.ErrBadRequestf("You cannot delete last targer of the mission")
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		// Transitions are checked against the locked state so that two of them
+		// cannot both leave the same state.
+		current, err := uow.Missions().GetForUpdate(id)
+		if err == nil && current == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
 
+		} else if err != nil {
+			return err
+		}
+
+		if err := uc.checkTransition(current, state); err != nil {
+			return err
+		}
+
+		if state == models.MissionStateAssigned || state == models.MissionStateInProgress {
+			if err := uc.checkTeam(uow, current, 0); err != nil {
+				return err
+			}
+		}
+
+		if _, err := uow.Missions().SetState(models.MissionTransition{
+			MissionID: id,
+			FromState: current.State,
+			ToState:   state,
+			Actor:     actor,
+			Reason:    reason,
+		}); err != nil {
+			return err
+		}
+
+		mission, err = uow.Missions().GetByID(id)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	err = uc.missionRepository.DeleteTarget(id)
+	return mission, nil
+}
+
+func (uc *missionUseCase) ListTransitions(id uint) ([]models.MissionTransition, error) {
+	if _, err := uc.Get(id); err != nil {
+		return nil, err
+	}
+
+	return uc.missionRepository.ListTransitions(id)
+}
+
+// enforcePolicy is the single place where the rules of a mission's type are
+// applied: how many targets it may have and what may be deleted from it.
+func (uc *missionUseCase) enforcePolicy(mission *models.Mission, change missionChange) error {
+	policy, err := uc.missionTypes.Get(mission.Type)
+
+	if policy == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission type with such code"))
+		return apperrors.ErrBadRequestf("There is no mission type with such code")
+	}
 
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
+	if change.deleteMission {
+		if mission.IsAssigned() && !policy.AllowDeleteAssigned {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Assigned mission cannot be deleted"))
+			return apperrors.ErrBadRequestf("Assigned mission cannot be deleted")
+		}
+		return nil
+	}
 
-func (uc *missionUseCase) AddTarget(missionId uint, target models.Target) (*models.Target, error) {
-	mission, err := uc.missionRepository.GetByID(missionId)
-	if err == nil && mission == nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
-		return nil, apperrors.ErrBadRequestf("There is no mission with such id")
+	if change.deletedTarget != nil && change.deletedTarget.IsCompleted && !policy.AllowDeleteCompletedTargets {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed target cannot be deleted"))
+		return apperrors.ErrBadRequestf("Completed target cannot be deleted")
+	}
 
-	} else if err != nil {
-		return nil, err
+	var msg string
+
+	switch {
+	case change.deletedTarget != nil:
+		if !policy.AllowsTargetRemoval(change.targetCount) {
+			msg = fmt.Sprintf("Target limit exceeded: %s missions need at least %d targets", policy.Name, policy.MinTargets)
+		}
+	case change.addedTarget:
+		if !policy.AllowsTargetAddition(change.targetCount) {
+			msg = fmt.Sprintf("Target limit exceeded: %s missions allow at most %d targets", policy.Name, policy.MaxTargets)
+		}
+	default:
+		if !policy.AllowsTargetCount(change.targetCount) {
+			msg = fmt.Sprintf("Target limit exceeded: %s missions need between %d and %d targets", policy.Name, policy.MinTargets, policy.MaxTargets)
+		}
 	}
 
-	if mission.IsCompleted {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed mission cannot be updated with new targets"))
-		return nil, apperrors.ErrBadRequestf("Completed mission cannot be updated with new targets")
+	if msg != "" {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return apperrors.ErrBadRequestf(msg)
 	}
 
-	if len(mission.TargetList) == 3 {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Target limit exceeded"))
-		return nil, apperrors.ErrBadRequestf("Target limit exceeded")
+	return nil
+}
+
+// checkDeadline accepts a missing deadline, otherwise it must lie in the future
+// and, when limit is set, not after it.
+func (uc *missionUseCase) checkDeadline(dueAt, limit *time.Time) error {
+	if dueAt == nil {
+		return nil
 	}
 
-	createdTarget, err := uc.missionRepository.AddTarget(missionId, target)
+	if !dueAt.After(time.Now()) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Deadline must be in the future"))
+		return apperrors.ErrBadRequestf("Deadline must be in the future")
+	}
 
-	if err != nil {
-		return nil, err
+	if limit != nil && dueAt.After(*limit) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Target deadline must not be later than the mission deadline"))
+		return apperrors.ErrBadRequestf("Target deadline must not be later than the mission deadline")
 	}
 
-	return createdTarget, nil
+	return nil
 }
 
-func (uc *missionUseCase) CompleteTarget(id uint) (*models.Target, error) {
-	var allTargetsCompleted = true
+func (uc *missionUseCase) checkTransition(mission *models.Mission, state string) error {
+	if !models.CanTransitionMission(mission.State, state) {
+		msg := fmt.Sprintf("Mission cannot move from %s to %s", mission.State, state)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return apperrors.ErrBadRequestf(msg)
+	}
+
+	switch state {
+	case models.MissionStateDraft:
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Use unassign to return a mission to draft"))
+		return apperrors.ErrBadRequestf("Use unassign to return a mission to draft")
+	case models.MissionStateAssigned, models.MissionStateInProgress:
+		if mission.CatId == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Mission has no cat assigned"))
+			return apperrors.ErrBadRequestf("Mission has no cat assigned")
+		}
+	case models.MissionStateCompleted:
+		for _, target := range mission.TargetList {
+			if !target.IsCompleted {
+				uc.logger.Warnf(apperrors.ErrBadRequestMsg("Mission has open targets"))
+				return apperrors.ErrBadRequestf("Mission has open targets")
+			}
+		}
+	}
+
+	return nil
+}
 
+func (uc *missionUseCase) GetTarget(id uint) (*models.Target, error) {
 	target, err := uc.missionRepository.GetTarget(id)
 
 	if err == nil && target == nil {
@@ -308,55 +935,191 @@ func (uc *missionUseCase) CompleteTarget(id uint) (*models.Target, error) {
 		return nil, err
 	}
 
-	if target.IsCompleted {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed target cannot be updated"))
-		return nil, apperrors.ErrBadRequestf("Completed target cannot be updated")
-	}
+	return target, nil
+}
 
-	mission, err := uc.missionRepository.GetByID(target.MissionID)
-	if err == nil && mission == nil {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
-		return nil, apperrors.ErrBadRequestf("There is no mission with such id")
+func (uc *missionUseCase) DeleteTarget(id uint) error {
+	return uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		target, err := uow.Missions().GetTarget(id)
+
+		if err == nil && target == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no target with such id"))
+			return apperrors.ErrBadRequestf("There is no target with such id")
+		} else if err != nil {
+			return err
+		}
 
-	} else if err != nil {
-		return nil, err
-	}
+		// The lock keeps concurrent deletes from each passing the minimum
+		// target check against the same count.
+		mission, err := uow.Missions().GetForUpdate(target.MissionID)
 
-	if mission.IsCompleted {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Target of completed mission cannot be updated"))
-		return nil, apperrors.ErrBadRequestf("Target of completed mission cannot be updated")
-	}
+		if err != nil {
+			return err
+		}
+
+		if mission == nil || !mission.HasTarget(id) {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no target with such id"))
+			return apperrors.ErrBadRequestf("There is no target with such id")
+		}
+
+		if mission.IsClosed() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Targets cannot be deleted from closed missions"))
+			return apperrors.ErrBadRequestf("Targets cannot be deleted from closed missions")
+		}
+
+		if err := uc.enforcePolicy(mission, missionChange{targetCount: len(mission.TargetList) - 1, deletedTarget: target}); err != nil {
+			return err
+		}
 
-	updatedTarget, err := uc.missionRepository.CompleteTarget(id)
+		return uow.Missions().DeleteTarget(id)
+	})
+}
+
+func (uc *missionUseCase) AddTarget(missionId uint, target models.Target) (*models.Target, error) {
+	var createdTarget *models.Target
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		mission, err := uow.Missions().GetForUpdate(missionId)
+		if err == nil && mission == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
+
+		if mission.IsClosed() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission cannot be updated with new targets"))
+			return apperrors.ErrBadRequestf("Closed mission cannot be updated with new targets")
+		}
+
+		if err := uc.enforcePolicy(mission, missionChange{targetCount: len(mission.TargetList) + 1, addedTarget: true}); err != nil {
+			return err
+		}
+
+		if err := uc.checkDeadline(target.DueAt, mission.DueAt); err != nil {
+			return err
+		}
+
+		createdTarget, err = uow.Missions().AddTarget(missionId, target)
+		return err
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	for _, v := range mission.TargetList {
-		if v.ID == id {
-			continue
+	return createdTarget, nil
+}
+
+// CompleteTarget marks the target done on behalf of a team member, the lead
+// when completedBy is not given.
+func (uc *missionUseCase) CompleteTarget(id uint, completedBy *uint) (*models.Target, error) {
+	var updatedTarget *models.Target
+
+	err := uc.txManager.WithinTransaction(func(uow UnitOfWork) error {
+		var allTargetsCompleted = true
+
+		target, err := uow.Missions().GetTarget(id)
+
+		if err == nil && target == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no target with such id"))
+			return apperrors.ErrBadRequestf("There is no target with such id")
+		} else if err != nil {
+			return err
 		}
-		if !v.IsCompleted {
-			allTargetsCompleted = false
-			break
+
+		if target.IsCompleted {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed target cannot be updated"))
+			return apperrors.ErrBadRequestf("Completed target cannot be updated")
 		}
-	}
 
-	uc.logger.Warnf("%v", allTargetsCompleted)
+		// The lock serializes completions on the mission, so the last one sees
+		// every other target completed and closes the mission.
+		mission, err := uow.Missions().GetForUpdate(target.MissionID)
+		if err == nil && mission == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
+			return apperrors.ErrBadRequestf("There is no mission with such id")
+
+		} else if err != nil {
+			return err
+		}
 
-	if allTargetsCompleted {
-		err = uc.missionRepository.Update(mission.ID, true)
+		if locked := mission.Target(id); locked == nil {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no target with such id"))
+			return apperrors.ErrBadRequestf("There is no target with such id")
+		} else if locked.IsCompleted {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed target cannot be updated"))
+			return apperrors.ErrBadRequestf("Completed target cannot be updated")
+		}
+
+		if mission.IsClosed() {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Target of closed mission cannot be updated"))
+			return apperrors.ErrBadRequestf("Target of closed mission cannot be updated")
+		}
+
+		if completedBy == nil {
+			completedBy = mission.CatId
+		}
+
+		if completedBy != nil {
+			member := mission.Member(*completedBy)
+			if member == nil {
+				uc.logger.Warnf(apperrors.ErrBadRequestMsg("Only team members can complete targets"))
+				return apperrors.ErrBadRequestf("Only team members can complete targets")
+			}
+
+			if member.Role == models.MemberRoleObserver {
+				uc.logger.Warnf(apperrors.ErrBadRequestMsg("Observers cannot complete targets"))
+				return apperrors.ErrBadRequestf("Observers cannot complete targets")
+			}
+		}
+
+		updatedTarget, err = uow.Missions().CompleteTarget(id, completedBy)
 
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		for _, v := range mission.TargetList {
+			if v.ID == id {
+				continue
+			}
+			if !v.IsCompleted {
+				allTargetsCompleted = false
+				break
+			}
 		}
+
+		next := ""
+		if allTargetsCompleted {
+			next = models.MissionStateCompleted
+		} else if mission.State == models.MissionStateAssigned {
+			next = models.MissionStateInProgress
+		}
+
+		if next == "" || !models.CanTransitionMission(mission.State, next) {
+			return nil
+		}
+
+		_, err = uow.Missions().SetState(models.MissionTransition{
+			MissionID: mission.ID,
+			FromState: mission.State,
+			ToState:   next,
+			Actor:     SystemActor,
+			Reason:    fmt.Sprintf("Target %d completed", id),
+		})
+		return err
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return updatedTarget, nil
 }
 
-func (uc *missionUseCase) UpdateTargetNotes(id uint, notes string) (*models.Target, error) {
+func (uc *missionUseCase) UpdateTarget(id uint, update models.TargetUpdate) (*models.Target, error) {
 	target, err := uc.missionRepository.GetTarget(id)
 
 	if err == nil && target == nil {
@@ -371,7 +1134,7 @@ func (uc *missionUseCase) UpdateTargetNotes(id uint, notes string) (*models.Targ
 		return nil, apperrors.ErrBadRequestf("Completed target cannot be updated")
 	}
 
-	mission, err := uc.missionRepository.GetByID(id)
+	mission, err := uc.missionRepository.GetByID(target.MissionID)
 	if err == nil && mission == nil {
 		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no mission with such id"))
 		return nil, apperrors.ErrBadRequestf("There is no mission with such id")
@@ -380,12 +1143,16 @@ func (uc *missionUseCase) UpdateTargetNotes(id uint, notes string) (*models.Targ
 		return nil, err
 	}
 
-	if mission.IsCompleted {
-		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Completed mission cannot be updated"))
-		return nil, apperrors.ErrBadRequestf("Completed mission cannot be updated")
+	if mission.IsClosed() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Closed mission cannot be updated"))
+		return nil, apperrors.ErrBadRequestf("Closed mission cannot be updated")
+	}
+
+	if err := uc.checkDeadline(update.DueAt, mission.DueAt); err != nil {
+		return nil, err
 	}
 
-	target, err = uc.missionRepository.UpdateTargetNotes(id, notes)
+	target, err = uc.missionRepository.UpdateTarget(id, update)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,751 @@
+package usecases
+
+import (
+	"errors"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"testing"
+	"time"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Infof(string, ...interface{})  {}
+func (nopLogger) Warnf(string, ...interface{})  {}
+func (nopLogger) Fatalf(string, ...interface{}) {}
+
+// fakeMissionRepository keeps missions in memory. The embedded interface is
+// nil, so a use case reaching for a method the fake does not implement panics
+// instead of passing silently.
+type fakeMissionRepository struct {
+	MissionRepositoryInterface
+
+	missions    map[uint]*models.Mission
+	transitions []models.MissionTransition
+	handovers   []models.Handover
+	locked      []uint
+	deleted     []uint
+}
+
+func newFakeMissionRepository(missions ...models.Mission) *fakeMissionRepository {
+	r := &fakeMissionRepository{missions: map[uint]*models.Mission{}}
+	for i := range missions {
+		r.missions[missions[i].ID] = copyMission(missions[i])
+	}
+	return r
+}
+
+func copyMission(mission models.Mission) *models.Mission {
+	mission.TargetList = append([]models.Target{}, mission.TargetList...)
+	mission.Team = append([]models.MissionMember{}, mission.Team...)
+	return &mission
+}
+
+func (r *fakeMissionRepository) GetByID(id uint) (*models.Mission, error) {
+	mission, ok := r.missions[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyMission(*mission), nil
+}
+
+func (r *fakeMissionRepository) GetForUpdate(id uint) (*models.Mission, error) {
+	r.locked = append(r.locked, id)
+	return r.GetByID(id)
+}
+
+func (r *fakeMissionRepository) GetActiveByCatID(catID uint) (*models.Mission, error) {
+	for id, mission := range r.missions {
+		if mission.State != models.MissionStateAssigned && mission.State != models.MissionStateInProgress {
+			continue
+		}
+		if mission.Member(catID) != nil {
+			return r.GetByID(id)
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeMissionRepository) SetState(transition models.MissionTransition) (*models.MissionTransition, error) {
+	r.missions[transition.MissionID].State = transition.ToState
+	r.transitions = append(r.transitions, transition)
+	return &transition, nil
+}
+
+func (r *fakeMissionRepository) AssignToCat(missionID, catID uint) error {
+	mission := r.missions[missionID]
+	now := time.Now()
+
+	team := make([]models.MissionMember, 0, len(mission.Team)+1)
+	for _, member := range mission.Team {
+		if member.Role == models.MemberRoleLead || member.CatID == catID {
+			continue
+		}
+		team = append(team, member)
+	}
+
+	mission.CatId = &catID
+	mission.AssignedAt = &now
+	mission.Team = append(team, models.MissionMember{MissionID: missionID, CatID: catID, Role: models.MemberRoleLead, JoinedAt: now})
+	return nil
+}
+
+func (r *fakeMissionRepository) UnassignCat(missionID uint) error {
+	mission := r.missions[missionID]
+
+	team := make([]models.MissionMember, 0, len(mission.Team))
+	for _, member := range mission.Team {
+		if member.Role != models.MemberRoleLead {
+			team = append(team, member)
+		}
+	}
+
+	mission.CatId = nil
+	mission.AssignedAt = nil
+	mission.Team = team
+	return nil
+}
+
+func (r *fakeMissionRepository) AddHandover(handover models.Handover) (*models.Handover, error) {
+	handover.ID = uint(len(r.handovers) + 1)
+	r.handovers = append(r.handovers, handover)
+	return &handover, nil
+}
+
+func (r *fakeMissionRepository) Delete(id uint) error {
+	delete(r.missions, id)
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *fakeMissionRepository) GetTarget(id uint) (*models.Target, error) {
+	for _, mission := range r.missions {
+		if target := mission.Target(id); target != nil {
+			res := *target
+			return &res, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeMissionRepository) CompleteTarget(id uint, completedBy *uint) (*models.Target, error) {
+	for _, mission := range r.missions {
+		if target := mission.Target(id); target != nil {
+			target.IsCompleted = true
+			target.CompletedBy = completedBy
+			res := *target
+			return &res, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeMissionRepository) DeleteTarget(id uint) error {
+	for _, mission := range r.missions {
+		for i, target := range mission.TargetList {
+			if target.ID == id {
+				mission.TargetList = append(mission.TargetList[:i], mission.TargetList[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeMissionRepository) AddTarget(missionID uint, target models.Target) (*models.Target, error) {
+	mission := r.missions[missionID]
+	target.ID = uint(100 + len(mission.TargetList))
+	target.MissionID = missionID
+	mission.TargetList = append(mission.TargetList, target)
+	return &target, nil
+}
+
+type fakeCatRepository struct {
+	CatRepositoryInterface
+
+	cats   map[uint]*models.Cat
+	leaves map[uint][]models.Leave
+}
+
+func newFakeCatRepository(cats ...models.Cat) *fakeCatRepository {
+	r := &fakeCatRepository{cats: map[uint]*models.Cat{}, leaves: map[uint][]models.Leave{}}
+	for i := range cats {
+		cat := cats[i]
+		r.cats[cat.ID] = &cat
+	}
+	return r
+}
+
+func (r *fakeCatRepository) Get(id uint) (*models.Cat, error) {
+	cat, ok := r.cats[id]
+	if !ok {
+		return nil, nil
+	}
+
+	res := *cat
+	return &res, nil
+}
+
+func (r *fakeCatRepository) ListOverlappingLeaves(catID uint, from time.Time, to *time.Time) ([]models.Leave, error) {
+	var overlapping []models.Leave
+	for _, leave := range r.leaves[catID] {
+		if leave.EndDate.Before(from) || (to != nil && leave.StartDate.After(*to)) {
+			continue
+		}
+		overlapping = append(overlapping, leave)
+	}
+	return overlapping, nil
+}
+
+type fakeSkillRepository struct {
+	SkillRepositoryInterface
+}
+
+func (fakeSkillRepository) ListCatSkills(catID uint) ([]models.CatSkill, error) {
+	return nil, nil
+}
+
+type fakeMissionTypeRepository struct {
+	MissionTypeRepositoryInterface
+
+	types map[string]models.MissionType
+}
+
+func (r fakeMissionTypeRepository) Get(code string) (*models.MissionType, error) {
+	missionType, ok := r.types[code]
+	if !ok {
+		return nil, nil
+	}
+	return &missionType, nil
+}
+
+type fakeUnitOfWork struct {
+	cats     *fakeCatRepository
+	missions *fakeMissionRepository
+	skills   fakeSkillRepository
+}
+
+func (u *fakeUnitOfWork) Cats() CatRepositoryInterface         { return u.cats }
+func (u *fakeUnitOfWork) Missions() MissionRepositoryInterface { return u.missions }
+func (u *fakeUnitOfWork) Skills() SkillRepositoryInterface     { return u.skills }
+
+// fakeTransactionManager runs fn against the fakes without rolling anything
+// back, which is enough for use cases that fail before they write.
+type fakeTransactionManager struct {
+	uow *fakeUnitOfWork
+}
+
+func (m fakeTransactionManager) WithinTransaction(fn func(uow UnitOfWork) error) error {
+	return fn(m.uow)
+}
+
+type missionFixture struct {
+	uc       *missionUseCase
+	missions *fakeMissionRepository
+	cats     *fakeCatRepository
+}
+
+func newMissionFixture(missionType models.MissionType, cats []models.Cat, missions ...models.Mission) missionFixture {
+	missionRepo := newFakeMissionRepository(missions...)
+	catRepo := newFakeCatRepository(cats...)
+	types := fakeMissionTypeRepository{types: map[string]models.MissionType{missionType.Code: missionType}}
+	uow := &fakeUnitOfWork{cats: catRepo, missions: missionRepo}
+
+	return missionFixture{
+		uc:       NewMissionUseCase(nopLogger{}, missionRepo, catRepo, fakeSkillRepository{}, types, fakeTransactionManager{uow: uow}, 30*24*time.Hour),
+		missions: missionRepo,
+		cats:     catRepo,
+	}
+}
+
+var standardMissionType = models.MissionType{Code: models.DefaultMissionType, Name: "Standard", MinTargets: 1, MaxTargets: 3}
+
+func lead(missionID, catID uint) models.MissionMember {
+	return models.MissionMember{MissionID: missionID, CatID: catID, Role: models.MemberRoleLead}
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func assertStatus(t *testing.T, err error, want int) {
+	t.Helper()
+
+	if want == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("error = %v, want status %d", err, want)
+	}
+	if appErr.Status() != want {
+		t.Fatalf("status = %d (%s), want %d", appErr.Status(), appErr.Error(), want)
+	}
+}
+
+func TestMissionTransition(t *testing.T) {
+	openTargets := []models.Target{{ID: 1, MissionID: 1}, {ID: 2, MissionID: 1, IsCompleted: true}}
+	doneTargets := []models.Target{{ID: 1, MissionID: 1, IsCompleted: true}}
+
+	tests := []struct {
+		name       string
+		mission    models.Mission
+		to         string
+		wantStatus int
+	}{
+		{
+			name:    "assigned mission starts",
+			mission: models.Mission{State: models.MissionStateAssigned, CatId: uintPtr(1), Team: []models.MissionMember{lead(1, 1)}, TargetList: openTargets},
+			to:      models.MissionStateInProgress,
+		},
+		{
+			name:    "finished mission completes",
+			mission: models.Mission{State: models.MissionStateInProgress, CatId: uintPtr(1), Team: []models.MissionMember{lead(1, 1)}, TargetList: doneTargets},
+			to:      models.MissionStateCompleted,
+		},
+		{
+			name:       "mission with open targets cannot complete",
+			mission:    models.Mission{State: models.MissionStateInProgress, CatId: uintPtr(1), Team: []models.MissionMember{lead(1, 1)}, TargetList: openTargets},
+			to:         models.MissionStateCompleted,
+			wantStatus: 400,
+		},
+		{
+			name:       "draft cannot skip to completed",
+			mission:    models.Mission{State: models.MissionStateDraft, TargetList: doneTargets},
+			to:         models.MissionStateCompleted,
+			wantStatus: 400,
+		},
+		{
+			name:       "draft without a cat cannot be assigned",
+			mission:    models.Mission{State: models.MissionStateDraft, TargetList: openTargets},
+			to:         models.MissionStateAssigned,
+			wantStatus: 400,
+		},
+		{
+			name:       "return to draft goes through unassign",
+			mission:    models.Mission{State: models.MissionStateAssigned, CatId: uintPtr(1), Team: []models.MissionMember{lead(1, 1)}, TargetList: openTargets},
+			to:         models.MissionStateDraft,
+			wantStatus: 400,
+		},
+		{
+			name:       "closed mission stays closed",
+			mission:    models.Mission{State: models.MissionStateAborted, TargetList: openTargets},
+			to:         models.MissionStateInProgress,
+			wantStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mission.ID = 1
+			tt.mission.Type = models.DefaultMissionType
+			f := newMissionFixture(standardMissionType, []models.Cat{{ID: 1}}, tt.mission)
+
+			_, err := f.uc.Transition(1, tt.to, "handler", "because")
+			assertStatus(t, err, tt.wantStatus)
+
+			if len(f.missions.locked) == 0 || f.missions.locked[0] != 1 {
+				t.Errorf("mission was not locked before the transition was checked")
+			}
+
+			if tt.wantStatus != 0 {
+				if len(f.missions.transitions) != 0 {
+					t.Errorf("rejected transition was recorded: %+v", f.missions.transitions)
+				}
+				return
+			}
+
+			if len(f.missions.transitions) != 1 {
+				t.Fatalf("recorded %d transitions, want 1", len(f.missions.transitions))
+			}
+			got := f.missions.transitions[0]
+			if got.FromState != tt.mission.State || got.ToState != tt.to || got.Actor != "handler" || got.Reason != "because" {
+				t.Errorf("transition = %+v", got)
+			}
+		})
+	}
+}
+
+func TestMissionUpdateCompletesAsSystemWithoutActor(t *testing.T) {
+	mission := models.Mission{
+		ID:         1,
+		Type:       models.DefaultMissionType,
+		State:      models.MissionStateInProgress,
+		CatId:      uintPtr(1),
+		Team:       []models.MissionMember{lead(1, 1)},
+		TargetList: []models.Target{{ID: 1, MissionID: 1, IsCompleted: true}},
+	}
+	f := newMissionFixture(standardMissionType, []models.Cat{{ID: 1}}, mission)
+
+	if _, err := f.uc.Update(1, true, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(f.missions.transitions) != 1 || f.missions.transitions[0].Actor != SystemActor {
+		t.Errorf("transitions = %+v, want one by %q", f.missions.transitions, SystemActor)
+	}
+}
+
+func TestMissionCompleteTargetMovesState(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     string
+		targets   []models.Target
+		wantState string
+	}{
+		{
+			name:      "first target starts the mission",
+			state:     models.MissionStateAssigned,
+			targets:   []models.Target{{ID: 1, MissionID: 1}, {ID: 2, MissionID: 1}},
+			wantState: models.MissionStateInProgress,
+		},
+		{
+			name:      "last target completes the mission",
+			state:     models.MissionStateInProgress,
+			targets:   []models.Target{{ID: 1, MissionID: 1}, {ID: 2, MissionID: 1, IsCompleted: true}},
+			wantState: models.MissionStateCompleted,
+		},
+		{
+			name:      "other targets leave a running mission alone",
+			state:     models.MissionStateInProgress,
+			targets:   []models.Target{{ID: 1, MissionID: 1}, {ID: 2, MissionID: 1}},
+			wantState: models.MissionStateInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mission := models.Mission{
+				ID:         1,
+				Type:       models.DefaultMissionType,
+				State:      tt.state,
+				CatId:      uintPtr(1),
+				Team:       []models.MissionMember{lead(1, 1)},
+				TargetList: tt.targets,
+			}
+			f := newMissionFixture(standardMissionType, []models.Cat{{ID: 1}}, mission)
+
+			target, err := f.uc.CompleteTarget(1, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if target.CompletedBy == nil || *target.CompletedBy != 1 {
+				t.Errorf("completed by = %v, want the lead", target.CompletedBy)
+			}
+			if got := f.missions.missions[1].State; got != tt.wantState {
+				t.Errorf("state = %s, want %s", got, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestMissionCompleteTargetRejectsCompletedTarget(t *testing.T) {
+	mission := models.Mission{
+		ID:         1,
+		Type:       models.DefaultMissionType,
+		State:      models.MissionStateInProgress,
+		CatId:      uintPtr(1),
+		Team:       []models.MissionMember{lead(1, 1)},
+		TargetList: []models.Target{{ID: 1, MissionID: 1, IsCompleted: true}, {ID: 2, MissionID: 1}},
+	}
+	f := newMissionFixture(standardMissionType, []models.Cat{{ID: 1}}, mission)
+
+	_, err := f.uc.CompleteTarget(1, nil)
+	assertStatus(t, err, 400)
+
+	if len(f.missions.transitions) != 0 {
+		t.Errorf("transitions = %+v, want none", f.missions.transitions)
+	}
+}
+
+func TestMissionHandover(t *testing.T) {
+	assigned := models.Mission{
+		ID:         1,
+		Type:       models.DefaultMissionType,
+		State:      models.MissionStateInProgress,
+		CatId:      uintPtr(1),
+		Team:       []models.MissionMember{lead(1, 1)},
+		TargetList: []models.Target{{ID: 1, MissionID: 1}},
+	}
+	busy := models.Mission{
+		ID:         2,
+		Type:       models.DefaultMissionType,
+		State:      models.MissionStateAssigned,
+		CatId:      uintPtr(3),
+		Team:       []models.MissionMember{lead(2, 3)},
+		TargetList: []models.Target{{ID: 2, MissionID: 2}},
+	}
+	draft := models.Mission{ID: 1, Type: models.DefaultMissionType, State: models.MissionStateDraft, TargetList: []models.Target{{ID: 1, MissionID: 1}}}
+	closed := assigned
+	closed.State = models.MissionStateCompleted
+
+	tests := []struct {
+		name       string
+		mission    models.Mission
+		reassignTo uint
+		actor      string
+		wantStatus int
+		wantActor  string
+	}{
+		{name: "unassign records the handover", mission: assigned, actor: "handler", wantActor: "handler"},
+		{name: "unassign without actor is recorded as system", mission: assigned, wantActor: SystemActor},
+		{name: "reassign records the new lead", mission: assigned, reassignTo: 2, actor: "handler", wantActor: "handler"},
+		{name: "reassign to the same cat", mission: assigned, reassignTo: 1, wantStatus: 400},
+		{name: "reassign to a cat on another active mission", mission: assigned, reassignTo: 3, wantStatus: 409},
+		{name: "unassign a mission without a cat", mission: draft, wantStatus: 400},
+		{name: "reassign a closed mission", mission: closed, reassignTo: 2, wantStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMissionFixture(standardMissionType, []models.Cat{{ID: 1}, {ID: 2}, {ID: 3}}, tt.mission, busy)
+
+			var err error
+			if tt.reassignTo != 0 {
+				_, err = f.uc.Reassign(1, tt.reassignTo, "swap", tt.actor)
+			} else {
+				_, err = f.uc.Unassign(1, "swap", tt.actor)
+			}
+			assertStatus(t, err, tt.wantStatus)
+
+			if len(f.missions.locked) == 0 || f.missions.locked[0] != 1 {
+				t.Errorf("mission was not locked before the handover was checked")
+			}
+
+			if tt.wantStatus != 0 {
+				if len(f.missions.handovers) != 0 {
+					t.Errorf("rejected handover was recorded: %+v", f.missions.handovers)
+				}
+				return
+			}
+
+			if len(f.missions.handovers) != 1 {
+				t.Fatalf("recorded %d handovers, want 1", len(f.missions.handovers))
+			}
+			handover := f.missions.handovers[0]
+			if handover.FromCatID != 1 || handover.Actor != tt.wantActor || handover.Reason != "swap" {
+				t.Errorf("handover = %+v", handover)
+			}
+
+			mission := f.missions.missions[1]
+			if tt.reassignTo != 0 {
+				if handover.ToCatID == nil || *handover.ToCatID != tt.reassignTo {
+					t.Errorf("handover to = %v, want %d", handover.ToCatID, tt.reassignTo)
+				}
+				if mission.CatId == nil || *mission.CatId != tt.reassignTo || mission.Member(1) != nil {
+					t.Errorf("lead = %v, team = %+v", mission.CatId, mission.Team)
+				}
+				if mission.State != tt.mission.State {
+					t.Errorf("state = %s, want %s", mission.State, tt.mission.State)
+				}
+				return
+			}
+
+			if handover.ToCatID != nil {
+				t.Errorf("handover to = %d, want none", *handover.ToCatID)
+			}
+			if mission.CatId != nil || mission.State != models.MissionStateDraft {
+				t.Errorf("cat = %v, state = %s, want an unassigned draft", mission.CatId, mission.State)
+			}
+		})
+	}
+}
+
+func TestMissionDeletePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		mission       models.Mission
+		allowAssigned bool
+		wantStatus    int
+	}{
+		{
+			name:    "draft mission",
+			mission: models.Mission{State: models.MissionStateDraft},
+		},
+		{
+			name:       "assigned mission",
+			mission:    models.Mission{State: models.MissionStateAssigned, CatId: uintPtr(1), Team: []models.MissionMember{lead(1, 1)}},
+			wantStatus: 400,
+		},
+		{
+			name:          "assigned mission of a type that allows it",
+			mission:       models.Mission{State: models.MissionStateAssigned, CatId: uintPtr(1), Team: []models.MissionMember{lead(1, 1)}},
+			allowAssigned: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missionType := standardMissionType
+			missionType.AllowDeleteAssigned = tt.allowAssigned
+			tt.mission.ID = 1
+			tt.mission.Type = missionType.Code
+			tt.mission.TargetList = []models.Target{{ID: 1, MissionID: 1}}
+			f := newMissionFixture(missionType, []models.Cat{{ID: 1}}, tt.mission)
+
+			err := f.uc.Delete(1)
+			assertStatus(t, err, tt.wantStatus)
+
+			if len(f.missions.locked) == 0 || f.missions.locked[0] != 1 {
+				t.Errorf("mission was not locked before the policy was checked")
+			}
+			if deleted := len(f.missions.deleted) == 1; deleted != (tt.wantStatus == 0) {
+				t.Errorf("deleted = %v, want %v", f.missions.deleted, tt.wantStatus == 0)
+			}
+		})
+	}
+}
+
+func TestMissionTargetLimits(t *testing.T) {
+	targets := func(n int) []models.Target {
+		list := make([]models.Target, n)
+		for i := range list {
+			list[i] = models.Target{ID: uint(i + 1), MissionID: 1}
+		}
+		return list
+	}
+	// The policy tightened after the missions below were created.
+	missionType := models.MissionType{Code: "strict", Name: "Strict", MinTargets: 2, MaxTargets: 3}
+
+	tests := []struct {
+		name       string
+		targets    []models.Target
+		add        bool
+		wantStatus int
+	}{
+		{name: "delete from a mission above the maximum", targets: targets(4)},
+		{name: "delete below the minimum", targets: targets(2), wantStatus: 400},
+		{name: "add to a mission below the minimum", targets: targets(1), add: true},
+		{name: "add above the maximum", targets: targets(3), add: true, wantStatus: 400},
+		{
+			name:       "delete a completed target",
+			targets:    append([]models.Target{{ID: 1, MissionID: 1, IsCompleted: true}}, targets(4)[1:]...),
+			wantStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mission := models.Mission{ID: 1, Type: missionType.Code, State: models.MissionStateDraft, TargetList: tt.targets}
+			f := newMissionFixture(missionType, nil, mission)
+
+			var err error
+			if tt.add {
+				_, err = f.uc.AddTarget(1, models.Target{Name: "Jerry", Country: "UA"})
+			} else {
+				err = f.uc.DeleteTarget(1)
+			}
+			assertStatus(t, err, tt.wantStatus)
+
+			want := len(tt.targets)
+			if tt.wantStatus == 0 && tt.add {
+				want++
+			} else if tt.wantStatus == 0 {
+				want--
+			}
+			if got := len(f.missions.missions[1].TargetList); got != want {
+				t.Errorf("mission has %d targets, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestMissionTeamAvailability(t *testing.T) {
+	now := time.Now()
+	support := models.MissionMember{MissionID: 1, CatID: 2, Role: models.MemberRoleSupport}
+	busy := models.Mission{
+		ID:         2,
+		Type:       models.DefaultMissionType,
+		State:      models.MissionStateInProgress,
+		CatId:      uintPtr(3),
+		Team:       []models.MissionMember{lead(2, 3), {MissionID: 2, CatID: 4, Role: models.MemberRoleObserver}},
+		TargetList: []models.Target{{ID: 2, MissionID: 2}},
+	}
+
+	tests := []struct {
+		name       string
+		mission    models.Mission
+		member     models.MissionMember
+		fired      bool
+		onLeave    bool
+		transition string
+		wantStatus int
+	}{
+		{name: "assign with an available team", member: support},
+		{name: "assign with a member on leave", member: support, onLeave: true, wantStatus: 400},
+		{name: "assign with a fired member", member: support, fired: true, wantStatus: 400},
+		{
+			name:       "assign with a member observing another active mission",
+			member:     models.MissionMember{MissionID: 1, CatID: 4, Role: models.MemberRoleSupport},
+			wantStatus: 409,
+		},
+		{
+			name:       "start a mission whose member went on leave",
+			mission:    models.Mission{State: models.MissionStateAssigned, CatId: uintPtr(1)},
+			member:     support,
+			onLeave:    true,
+			transition: models.MissionStateInProgress,
+			wantStatus: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mission := tt.mission
+			if mission.State == "" {
+				mission.State = models.MissionStateDraft
+			}
+			mission.ID = 1
+			mission.Type = models.DefaultMissionType
+			mission.TargetList = []models.Target{{ID: 1, MissionID: 1}}
+			mission.Team = []models.MissionMember{tt.member}
+			if mission.CatId != nil {
+				mission.Team = append(mission.Team, lead(1, *mission.CatId))
+			}
+
+			member := models.Cat{ID: tt.member.CatID}
+			if tt.fired {
+				member.TerminatedAt = &now
+			}
+			f := newMissionFixture(standardMissionType, []models.Cat{{ID: 1}, member, {ID: 3}}, mission, busy)
+			if tt.onLeave {
+				f.cats.leaves[tt.member.CatID] = []models.Leave{{CatID: tt.member.CatID, StartDate: now.AddDate(0, 0, -1), EndDate: now.AddDate(0, 0, 2)}}
+			}
+
+			var err error
+			if tt.transition != "" {
+				_, err = f.uc.Transition(1, tt.transition, "handler", "")
+			} else {
+				_, err = f.uc.Assign(1, 1)
+			}
+			assertStatus(t, err, tt.wantStatus)
+
+			if got := f.missions.missions[1].State; tt.wantStatus != 0 && got != mission.State {
+				t.Errorf("state = %s, want %s", got, mission.State)
+			}
+			if got := f.missions.missions[1].State; tt.wantStatus == 0 && got != models.MissionStateAssigned {
+				t.Errorf("state = %s, want %s", got, models.MissionStateAssigned)
+			}
+		})
+	}
+}
+
+func TestMissionDeleteCountsTeamMembers(t *testing.T) {
+	mission := models.Mission{
+		ID:         1,
+		Type:       models.DefaultMissionType,
+		State:      models.MissionStateDraft,
+		Team:       []models.MissionMember{{MissionID: 1, CatID: 2, Role: models.MemberRoleSupport}},
+		TargetList: []models.Target{{ID: 1, MissionID: 1}},
+	}
+	f := newMissionFixture(standardMissionType, []models.Cat{{ID: 2}}, mission)
+
+	assertStatus(t, f.uc.Delete(1), 400)
+
+	if len(f.missions.deleted) != 0 {
+		t.Errorf("mission with a team was deleted")
+	}
+}
@@ -0,0 +1,195 @@
+package usecases
+
+import (
+	"math"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"time"
+)
+
+type (
+	PayrollRepositoryInterface interface {
+		Add(run models.PayrollRun) (*models.PayrollRun, error)
+		Get(id uint) (*models.PayrollRun, error)
+		HasOverlappingRun(start, end time.Time) (bool, error)
+	}
+
+	payrollUseCase struct {
+		logger            logger.Logger
+		payrollRepository PayrollRepositoryInterface
+		catRepository     CatRepositoryInterface
+	}
+)
+
+func NewPayrollUseCase(customLogger logger.Logger, payrollRepo PayrollRepositoryInterface, catRepo CatRepositoryInterface) *payrollUseCase {
+	return &payrollUseCase{
+		logger:            customLogger,
+		payrollRepository: payrollRepo,
+		catRepository:     catRepo,
+	}
+}
+
+// CreateRun builds a payroll run for the inclusive period [start, end]. Each day a cat
+// was employed pays the salary in effect that day, divided by the days in the period.
+// Periods may not overlap, so no day is paid twice.
+func (uc *payrollUseCase) CreateRun(start, end time.Time) (*models.PayrollRun, error) {
+	start, end = truncateToDay(start), truncateToDay(end)
+
+	if end.Before(start) {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Payroll period cannot end before it starts"))
+		return nil, apperrors.ErrBadRequestf("Payroll period cannot end before it starts")
+	}
+
+	overlapping, err := uc.payrollRepository.HasOverlappingRun(start, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if overlapping {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Payroll period overlaps an existing run"))
+		return nil, apperrors.ErrBadRequestf("Payroll period overlaps an existing run")
+	}
+
+	cats, err := uc.catRepository.ListEmployedBetween(start, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := uc.catRepository.ListEmploymentPeriods(start, end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := uc.catRepository.ListAppliedSalaryChanges(end)
+
+	if err != nil {
+		return nil, err
+	}
+
+	periodsByCat := make(map[uint][]models.EmploymentPeriod, len(cats))
+	for _, period := range periods {
+		periodsByCat[period.CatID] = append(periodsByCat[period.CatID], period)
+	}
+
+	changesByCat := make(map[uint][]models.SalaryChange, len(cats))
+	for _, change := range changes {
+		changesByCat[change.CatID] = append(changesByCat[change.CatID], change)
+	}
+
+	run := models.PayrollRun{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Lines:       make([]models.PayrollLine, 0, len(cats)),
+	}
+
+	for _, cat := range cats {
+		line, ok := prorate(cat, start, end, periodsByCat[cat.ID], changesByCat[cat.ID])
+		if !ok {
+			continue
+		}
+
+		run.Lines = append(run.Lines, line)
+		run.Total += line.Amount
+	}
+
+	run.Total = roundToCents(run.Total)
+
+	return uc.payrollRepository.Add(run)
+}
+
+func (uc *payrollUseCase) GetRun(id uint) (*models.PayrollRun, error) {
+	run, err := uc.payrollRepository.Get(id)
+
+	if run == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no payroll run with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no payroll run with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// prorate computes the cat's line for [start, end]. periods are the cat's
+// employment periods and changes its applied salary changes by effective date;
+// a change pays from the day it takes effect. Salary on the line is the one in
+// effect on the last day worked. It reports false when the cat worked no day.
+func prorate(cat models.Cat, start, end time.Time, periods []models.EmploymentPeriod, changes []models.SalaryChange) (models.PayrollLine, bool) {
+	daysInPeriod := daysBetween(start, end)
+
+	line := models.PayrollLine{
+		CatID:        cat.ID,
+		CatName:      cat.Name,
+		DaysInPeriod: daysInPeriod,
+	}
+
+	var amount float64
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if !employedOn(periods, day) {
+			continue
+		}
+
+		salary := salaryOn(cat, changes, day)
+
+		amount += salary / float64(daysInPeriod)
+		line.Salary = salary
+		line.DaysEmployed++
+	}
+
+	line.Amount = roundToCents(amount)
+
+	return line, line.DaysEmployed > 0
+}
+
+// employedOn reports whether one of the periods covers the day. The day of
+// termination still counts as worked.
+func employedOn(periods []models.EmploymentPeriod, day time.Time) bool {
+	for _, period := range periods {
+		if truncateToDay(period.StartedAt).After(day) {
+			continue
+		}
+		if period.EndedAt != nil && truncateToDay(*period.EndedAt).Before(day) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// salaryOn returns the salary in effect on the day: that of the last change
+// effective by then, or the salary before the first change.
+func salaryOn(cat models.Cat, changes []models.SalaryChange, day time.Time) float64 {
+	salary := cat.Salary
+	if len(changes) > 0 && changes[0].OldSalary != nil {
+		salary = *changes[0].OldSalary
+	}
+
+	for _, change := range changes {
+		if truncateToDay(change.EffectiveAt).After(day) {
+			break
+		}
+		salary = change.NewSalary
+	}
+
+	return salary
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts calendar days in the inclusive range [from, to].
+func daysBetween(from, to time.Time) uint {
+	return uint(to.Sub(from).Hours()/24) + 1
+}
+
+func roundToCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
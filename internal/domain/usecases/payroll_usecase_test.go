@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"spyCatAgency/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func TestProrate(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2026, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+	ptr := func(v time.Time) *time.Time { return &v }
+	salary := func(v float64) *float64 { return &v }
+
+	start, end := date(1), date(31)
+	cat := models.Cat{ID: 7, Name: "Tom", Salary: 3100}
+
+	tests := []struct {
+		name     string
+		cat      models.Cat
+		periods  []models.EmploymentPeriod
+		changes  []models.SalaryChange
+		want     models.PayrollLine
+		wantPaid bool
+	}{
+		{
+			name:     "employed all month",
+			cat:      cat,
+			periods:  []models.EmploymentPeriod{{StartedAt: date(1).AddDate(-1, 0, 0)}},
+			want:     models.PayrollLine{Salary: 3100, DaysEmployed: 31, Amount: 3100},
+			wantPaid: true,
+		},
+		{
+			name:     "hired mid month",
+			cat:      cat,
+			periods:  []models.EmploymentPeriod{{StartedAt: date(11).Add(9 * time.Hour)}},
+			want:     models.PayrollLine{Salary: 3100, DaysEmployed: 21, Amount: 2100},
+			wantPaid: true,
+		},
+		{
+			name:     "terminated mid month keeps the last day",
+			cat:      cat,
+			periods:  []models.EmploymentPeriod{{StartedAt: date(1), EndedAt: ptr(date(10).Add(15 * time.Hour))}},
+			want:     models.PayrollLine{Salary: 3100, DaysEmployed: 10, Amount: 1000},
+			wantPaid: true,
+		},
+		{
+			name: "rehired within the period",
+			cat:  cat,
+			periods: []models.EmploymentPeriod{
+				{StartedAt: date(1).AddDate(0, -2, 0), EndedAt: ptr(date(5))},
+				{StartedAt: date(21)},
+			},
+			want:     models.PayrollLine{Salary: 3100, DaysEmployed: 16, Amount: 1600},
+			wantPaid: true,
+		},
+		{
+			name:    "raise takes effect mid month",
+			cat:     models.Cat{ID: 7, Name: "Tom", Salary: 6200},
+			periods: []models.EmploymentPeriod{{StartedAt: date(1)}},
+			changes: []models.SalaryChange{
+				{OldSalary: salary(3100), NewSalary: 6200, EffectiveAt: date(16).Add(12 * time.Hour)},
+			},
+			want:     models.PayrollLine{Salary: 6200, DaysEmployed: 31, Amount: 4700},
+			wantPaid: true,
+		},
+		{
+			name:    "changes after the period are ignored",
+			cat:     models.Cat{ID: 7, Name: "Tom", Salary: 6200},
+			periods: []models.EmploymentPeriod{{StartedAt: date(1)}},
+			changes: []models.SalaryChange{
+				{OldSalary: salary(3100), NewSalary: 6200, EffectiveAt: date(31).AddDate(0, 0, 1)},
+			},
+			want:     models.PayrollLine{Salary: 3100, DaysEmployed: 31, Amount: 3100},
+			wantPaid: true,
+		},
+		{
+			name:     "not employed in the period",
+			cat:      cat,
+			periods:  []models.EmploymentPeriod{{StartedAt: date(31).AddDate(0, 0, 1)}},
+			want:     models.PayrollLine{},
+			wantPaid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.CatID = tt.cat.ID
+			tt.want.CatName = tt.cat.Name
+			tt.want.DaysInPeriod = 31
+
+			got, paid := prorate(tt.cat, start, end, tt.periods, tt.changes)
+
+			if paid != tt.wantPaid {
+				t.Fatalf("prorate() paid = %v, want %v", paid, tt.wantPaid)
+			}
+
+			if got != tt.want {
+				t.Errorf("prorate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
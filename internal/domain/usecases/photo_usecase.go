@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io/fs"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+)
+
+const ThumbnailSize = 256
+
+var allowedPhotoTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+type (
+	PhotoStorageInterface interface {
+		Save(key string, data []byte) error
+		Load(key string) ([]byte, error)
+		Delete(key string) error
+	}
+
+	photoUseCase struct {
+		logger        logger.Logger
+		catRepository CatRepositoryInterface
+		storage       PhotoStorageInterface
+	}
+)
+
+func NewPhotoUseCase(customLogger logger.Logger, catRepo CatRepositoryInterface, storage PhotoStorageInterface) *photoUseCase {
+	return &photoUseCase{
+		logger:        customLogger,
+		catRepository: catRepo,
+		storage:       storage,
+	}
+}
+
+func (uc *photoUseCase) Upload(catID uint, data []byte) (*models.Cat, error) {
+	if len(data) > models.MaxPhotoSize {
+		msg := fmt.Sprintf("Photo must not exceed %d bytes", models.MaxPhotoSize)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return nil, apperrors.ErrBadRequestf(msg)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedPhotoTypes[contentType]
+	if !ok {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Photo must be a JPEG or PNG image"))
+		return nil, apperrors.ErrBadRequestf("Photo must be a JPEG or PNG image")
+	}
+
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(err.Error()))
+		return nil, apperrors.ErrBadRequestf("Photo could not be decoded")
+	}
+
+	if config.Width*config.Height > models.MaxPhotoPixels {
+		msg := fmt.Sprintf("Photo must not exceed %d pixels", models.MaxPhotoPixels)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return nil, apperrors.ErrBadRequestf(msg)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(err.Error()))
+		return nil, apperrors.ErrBadRequestf("Photo could not be decoded")
+	}
+
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(img, ThumbnailSize), &jpeg.Options{Quality: 85}); err != nil {
+		uc.logger.Warnf(apperrors.ErrInternalMsg(err.Error()))
+		return nil, apperrors.ErrInternal
+	}
+
+	key := fmt.Sprintf("cats/%d/photo.%s", catID, ext)
+
+	if err := uc.storage.Save(key, data); err != nil {
+		uc.logger.Warnf(apperrors.ErrInternalMsg(err.Error()))
+		return nil, apperrors.ErrInternal
+	}
+
+	if err := uc.storage.Save(thumbnailKey(catID), thumb.Bytes()); err != nil {
+		uc.logger.Warnf(apperrors.ErrInternalMsg(err.Error()))
+		return nil, apperrors.ErrInternal
+	}
+
+	if cat.HasPhoto() && cat.PhotoKey != key {
+		if err := uc.storage.Delete(cat.PhotoKey); err != nil {
+			uc.logger.Warnf("Failed to delete old photo %s: %s", cat.PhotoKey, err.Error())
+		}
+	}
+
+	return uc.catRepository.SetPhoto(catID, key, contentType)
+}
+
+// Get returns the photo bytes and their content type, or the JPEG thumbnail when thumb is set.
+func (uc *photoUseCase) Get(catID uint, thumb bool) ([]byte, string, error) {
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, "", apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !cat.HasPhoto() {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Cat has no photo"))
+		return nil, "", apperrors.ErrBadRequestf("Cat has no photo")
+	}
+
+	key, contentType := cat.PhotoKey, cat.PhotoContentType
+	if thumb {
+		key, contentType = thumbnailKey(catID), "image/jpeg"
+	}
+
+	data, err := uc.storage.Load(key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg("Cat has no photo"))
+			return nil, "", apperrors.ErrBadRequestf("Cat has no photo")
+		}
+		uc.logger.Warnf(apperrors.ErrInternalMsg(err.Error()))
+		return nil, "", apperrors.ErrInternal
+	}
+
+	return data, contentType, nil
+}
+
+func thumbnailKey(catID uint) string {
+	return fmt.Sprintf("cats/%d/thumbnail.jpg", catID)
+}
+
+// thumbnail downscales src so that its longer side is at most maxSize, averaging the covered source pixels.
+func thumbnail(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	thumbWidth, thumbHeight := width, height
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			thumbWidth, thumbHeight = maxSize, max(1, height*maxSize/width)
+		} else {
+			thumbWidth, thumbHeight = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+
+	for y := 0; y < thumbHeight; y++ {
+		srcY0 := bounds.Min.Y + y*height/thumbHeight
+		srcY1 := max(srcY0+1, bounds.Min.Y+(y+1)*height/thumbHeight)
+
+		for x := 0; x < thumbWidth; x++ {
+			srcX0 := bounds.Min.X + x*width/thumbWidth
+			srcX1 := max(srcX0+1, bounds.Min.X+(x+1)*width/thumbWidth)
+
+			var r, g, b, a, n uint64
+			for sy := srcY0; sy < srcY1; sy++ {
+				for sx := srcX0; sx < srcX1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestThumbnail(t *testing.T) {
+	uniform := func(width, height int) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, width, height))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+			}
+		}
+		return img
+	}
+
+	tests := []struct {
+		name                  string
+		src                   image.Image
+		maxSize               int
+		wantWidth, wantHeight int
+	}{
+		{"landscape", uniform(400, 200), 100, 100, 50},
+		{"portrait", uniform(200, 400), 100, 50, 100},
+		{"square", uniform(300, 300), 100, 100, 100},
+		{"smaller than the limit", uniform(50, 30), 100, 50, 30},
+		{"thin strip keeps one pixel", uniform(1000, 2), 100, 100, 1},
+		{"offset bounds", uniform(400, 200).(*image.RGBA).SubImage(image.Rect(100, 50, 300, 150)), 50, 50, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := thumbnail(tt.src, tt.maxSize)
+
+			bounds := got.Bounds()
+			if bounds.Dx() != tt.wantWidth || bounds.Dy() != tt.wantHeight {
+				t.Fatalf("thumbnail() size = %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), tt.wantWidth, tt.wantHeight)
+			}
+
+			want := color.RGBAModel.Convert(tt.src.At(tt.src.Bounds().Min.X, tt.src.Bounds().Min.Y))
+			if c := color.RGBAModel.Convert(got.At(0, 0)); c != want {
+				t.Errorf("thumbnail() pixel = %v, want %v", c, want)
+			}
+		})
+	}
+}
+
+func TestThumbnailAveragesPixels(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 2, 1))
+	src.SetGray(0, 0, color.Gray{Y: 0})
+	src.SetGray(1, 0, color.Gray{Y: 255})
+
+	got := thumbnail(src, 1)
+
+	want := color.RGBA{R: 127, G: 127, B: 127, A: 255}
+	if c := color.RGBAModel.Convert(got.At(0, 0)); c != want {
+		t.Errorf("thumbnail() pixel = %v, want %v", c, want)
+	}
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"fmt"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+)
+
+type (
+	RankRepositoryInterface interface {
+		List() ([]models.Rank, error)
+		Get(code string) (*models.Rank, error)
+		Update(code string, update models.RankUpdate) (*models.Rank, error)
+		CountCatsOutsideBand(code string, minSalary, maxSalary float64) (uint, error)
+	}
+
+	rankUseCase struct {
+		logger         logger.Logger
+		rankRepository RankRepositoryInterface
+	}
+)
+
+func NewRankUseCase(customLogger logger.Logger, rankRepo RankRepositoryInterface) *rankUseCase {
+	return &rankUseCase{
+		logger:         customLogger,
+		rankRepository: rankRepo,
+	}
+}
+
+func (uc *rankUseCase) List() ([]models.Rank, error) {
+	return uc.rankRepository.List()
+}
+
+func (uc *rankUseCase) Update(code string, update models.RankUpdate) (*models.Rank, error) {
+	rank, err := uc.rankRepository.Get(code)
+
+	if rank == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no rank with such code"))
+		return nil, apperrors.ErrBadRequestf("There is no rank with such code")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	minSalary, maxSalary := rank.MinSalary, rank.MaxSalary
+	if update.MinSalary != nil {
+		minSalary = *update.MinSalary
+	}
+	if update.MaxSalary != nil {
+		maxSalary = *update.MaxSalary
+	}
+
+	if minSalary > maxSalary {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Minimum salary cannot exceed maximum salary"))
+		return nil, apperrors.ErrBadRequestf("Minimum salary cannot exceed maximum salary")
+	}
+
+	if update.Name == nil && update.MinYearsOfExperience == nil && update.MinCompletedMissions == nil && update.MinSalary == nil && update.MaxSalary == nil {
+		return rank, nil
+	}
+
+	if minSalary > rank.MinSalary || maxSalary < rank.MaxSalary {
+		outside, err := uc.rankRepository.CountCatsOutsideBand(code, minSalary, maxSalary)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if outside > 0 {
+			msg := fmt.Sprintf("%d cats of rank %s earn outside the new salary band", outside, rank.Name)
+			uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+			return nil, apperrors.ErrBadRequestf(msg)
+		}
+	}
+
+	return uc.rankRepository.Update(code, update)
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strings"
+)
+
+const MinSearchLength = 2
+
+var searchHitTypes = []string{models.SearchHitCat, models.SearchHitMission, models.SearchHitTarget}
+
+type (
+	SearchRepositoryInterface interface {
+		Search(query models.SearchQuery) ([]models.SearchHit, error)
+	}
+
+	searchUseCase struct {
+		logger           logger.Logger
+		searchRepository SearchRepositoryInterface
+	}
+)
+
+func NewSearchUseCase(customLogger logger.Logger, searchRepo SearchRepositoryInterface) *searchUseCase {
+	return &searchUseCase{
+		logger:           customLogger,
+		searchRepository: searchRepo,
+	}
+}
+
+func (uc *searchUseCase) Search(query models.SearchQuery) ([]models.SearchHit, error) {
+	query.Text = strings.TrimSpace(query.Text)
+
+	if len([]rune(query.Text)) < MinSearchLength {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("Search query is too short"))
+		return nil, apperrors.ErrBadRequestf("Search query is too short")
+	}
+
+	if len(query.Types) == 0 {
+		query.Types = searchHitTypes
+	}
+
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+
+	return uc.searchRepository.Search(query)
+}
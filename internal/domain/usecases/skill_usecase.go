@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"fmt"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strings"
+)
+
+type (
+	SkillRepositoryInterface interface {
+		Add(skill models.Skill) (*models.Skill, error)
+		Get(id uint) (*models.Skill, error)
+		List() ([]models.Skill, error)
+		SetCatSkill(catID, skillID, level uint) error
+		DeleteCatSkill(catID, skillID uint) error
+		ListCatSkills(catID uint) ([]models.CatSkill, error)
+	}
+
+	skillUseCase struct {
+		logger          logger.Logger
+		skillRepository SkillRepositoryInterface
+		catRepository   CatRepositoryInterface
+	}
+)
+
+func NewSkillUseCase(customLogger logger.Logger, skillRepo SkillRepositoryInterface, catRepo CatRepositoryInterface) *skillUseCase {
+	return &skillUseCase{
+		logger:          customLogger,
+		skillRepository: skillRepo,
+		catRepository:   catRepo,
+	}
+}
+
+func (uc *skillUseCase) Create(skill models.Skill) (*models.Skill, error) {
+	return uc.skillRepository.Add(skill)
+}
+
+func (uc *skillUseCase) List() ([]models.Skill, error) {
+	return uc.skillRepository.List()
+}
+
+func (uc *skillUseCase) ListCatSkills(catID uint) ([]models.CatSkill, error) {
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.skillRepository.ListCatSkills(catID)
+}
+
+func (uc *skillUseCase) SetCatSkill(catID, skillID, level uint) ([]models.CatSkill, error) {
+	if level < models.MinSkillLevel || level > models.MaxSkillLevel {
+		msg := fmt.Sprintf("Skill level must be between %d and %d", models.MinSkillLevel, models.MaxSkillLevel)
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg(msg))
+		return nil, apperrors.ErrBadRequestf(msg)
+	}
+
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	skill, err := uc.skillRepository.Get(skillID)
+
+	if skill == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no skill with such id"))
+		return nil, apperrors.ErrBadRequestf("There is no skill with such id")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.skillRepository.SetCatSkill(catID, skillID, level); err != nil {
+		return nil, err
+	}
+
+	return uc.skillRepository.ListCatSkills(catID)
+}
+
+func (uc *skillUseCase) RemoveCatSkill(catID, skillID uint) error {
+	cat, err := uc.catRepository.Get(catID)
+
+	if cat == nil && err == nil {
+		uc.logger.Warnf(apperrors.ErrBadRequestMsg("There is no cat with such id"))
+		return apperrors.ErrBadRequestf("There is no cat with such id")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return uc.skillRepository.DeleteCatSkill(catID, skillID)
+}
+
+// unmetRequirements describes every requirement the cat's skills fall short of.
+func unmetRequirements(requirements []models.SkillRequirement, catSkills []models.CatSkill) []string {
+	levels := make(map[uint]uint, len(catSkills))
+	for _, catSkill := range catSkills {
+		levels[catSkill.SkillID] = catSkill.Level
+	}
+
+	unmet := make([]string, 0)
+	for _, requirement := range requirements {
+		level, ok := levels[requirement.SkillID]
+		if !ok {
+			unmet = append(unmet, fmt.Sprintf("%s requires level %d, cat does not have this skill", requirement.SkillName, requirement.MinLevel))
+			continue
+		}
+		if level < requirement.MinLevel {
+			unmet = append(unmet, fmt.Sprintf("%s requires level %d, cat has level %d", requirement.SkillName, requirement.MinLevel, level))
+		}
+	}
+
+	return unmet
+}
+
+func skillRequirementsError(unmet []string) error {
+	return apperrors.ErrBadRequestf("Cat does not meet mission requirements: " + strings.Join(unmet, "; "))
+}
@@ -0,0 +1,16 @@
+package usecases
+
+type (
+	// UnitOfWork exposes repositories that share one database transaction.
+	UnitOfWork interface {
+		Cats() CatRepositoryInterface
+		Missions() MissionRepositoryInterface
+		Skills() SkillRepositoryInterface
+	}
+
+	// TransactionManagerInterface commits the unit of work when fn returns nil
+	// and rolls it back otherwise.
+	TransactionManagerInterface interface {
+		WithinTransaction(fn func(uow UnitOfWork) error) error
+	}
+)
@@ -7,6 +7,7 @@ import (
 
 const (
 	BadRequest Type = "BAD_REQUEST"
+	Conflict   Type = "CONFLICT"
 	Internal   Type = "INTERNAL"
 )
 
@@ -34,6 +35,8 @@ func (err *AppError) Status() int {
 	switch err.Type {
 	case BadRequest:
 		return http.StatusBadRequest
+	case Conflict:
+		return http.StatusConflict
 	case Internal:
 		return http.StatusInternalServerError
 	default:
@@ -68,3 +71,15 @@ func ErrBadRequestf(msg string) *AppError {
 	return New(BadRequest, fmt.Sprintf("Bad Request: %s", msg))
 
 }
+
+func ErrConflictMsg(msg string) string {
+
+	return fmt.Sprintf("Conflict: %s", msg)
+
+}
+
+func ErrConflictf(msg string) *AppError {
+
+	return New(Conflict, fmt.Sprintf("Conflict: %s", msg))
+
+}
@@ -0,0 +1,114 @@
+package catapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strings"
+	"time"
+)
+
+//go:embed breeds_snapshot.json
+var breedsSnapshot []byte
+
+type (
+	Client struct {
+		logger     logger.Logger
+		baseURL    string
+		httpClient *http.Client
+		attempts   int
+		backoff    time.Duration
+	}
+
+	breedDTO struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Origin string `json:"origin"`
+	}
+)
+
+func NewClient(customLogger logger.Logger, baseURL string, attempts int, backoff time.Duration) *Client {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return &Client{
+		logger:     customLogger,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		attempts:   attempts,
+		backoff:    backoff,
+	}
+}
+
+// FetchBreeds downloads the breed list, retrying with exponential backoff.
+func (c *Client) FetchBreeds() ([]models.Breed, error) {
+	var lastErr error
+	backoff := c.backoff
+
+	for attempt := 1; attempt <= c.attempts; attempt++ {
+		breeds, err := c.fetchBreeds()
+		if err == nil {
+			return breeds, nil
+		}
+
+		lastErr = err
+		c.logger.Warnf("Failed to fetch breeds (attempt %d/%d): %s", attempt, c.attempts, err.Error())
+
+		if attempt < c.attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return nil, lastErr
+}
+
+func (c *Client) fetchBreeds() ([]models.Breed, error) {
+	queryRes, err := c.httpClient.Get(c.baseURL + "/breeds")
+	if err != nil {
+		return nil, err
+	}
+	defer queryRes.Body.Close()
+
+	if queryRes.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", queryRes.Status)
+	}
+
+	body, err := io.ReadAll(queryRes.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBreeds(body)
+}
+
+// Snapshot returns the breed list embedded into the binary at build time.
+func Snapshot() ([]models.Breed, error) {
+	return parseBreeds(breedsSnapshot)
+}
+
+func parseBreeds(body []byte) ([]models.Breed, error) {
+	var dtoList []breedDTO
+	if err := json.Unmarshal(body, &dtoList); err != nil {
+		return nil, err
+	}
+
+	breeds := make([]models.Breed, 0, len(dtoList))
+	for _, dto := range dtoList {
+		if dto.ID == "" || dto.Name == "" {
+			continue
+		}
+		breeds = append(breeds, models.Breed{
+			ID:     dto.ID,
+			Name:   dto.Name,
+			Origin: dto.Origin,
+		})
+	}
+
+	return breeds, nil
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+)
+
+type (
+	breedRepository struct {
+		logger logger.Logger
+		*sql.DB
+	}
+)
+
+func NewBreedRepository(customLogger logger.Logger, r *sql.DB) *breedRepository {
+	return &breedRepository{
+		logger: customLogger,
+		DB:     r,
+	}
+}
+
+// Upsert stores breeds by their source id. A breed whose name is already held by
+// another id is skipped and logged, so that one upstream rename cannot abort the
+// whole refresh; it is picked up again once the name is free.
+func (r *breedRepository) Upsert(breeds []models.Breed) error {
+	query := `
+		INSERT INTO breeds (id, name, origin, updated_at)
+		SELECT $1::VARCHAR, $2::VARCHAR, $3::VARCHAR, NOW()
+		WHERE NOT EXISTS (SELECT 1 FROM breeds WHERE name = $2::VARCHAR AND id <> $1::VARCHAR)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, origin = EXCLUDED.origin, updated_at = NOW();
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	for _, breed := range breeds {
+		res, err := tx.ExecContext(ctx, query, breed.ID, breed.Name, breed.Origin)
+		if err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return apperrors.ErrDatabase
+		}
+
+		if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+			r.logger.Warnf("Skipping breed %s: name %s belongs to another breed", breed.ID, breed.Name)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (r *breedRepository) List() ([]models.Breed, error) {
+	list := []models.Breed{}
+	query := "SELECT id, name, origin, updated_at FROM breeds ORDER BY name;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.QueryContext(ctx, query)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var breed models.Breed
+		if err := rows.Scan(
+			&breed.ID,
+			&breed.Name,
+			&breed.Origin,
+			&breed.UpdatedAt,
+		); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, breed)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
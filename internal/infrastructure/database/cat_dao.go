@@ -4,43 +4,56 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"spyCatAgency/internal/domain/models"
 	"spyCatAgency/internal/infrastructure/apperrors"
 	"spyCatAgency/internal/infrastructure/logger"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const catColumns = "id, name, years_of_experience, breed, salary, rank, created_at, hired_at, terminated_at, termination_reason, photo_key, photo_content_type"
+
 type (
 	catRepository struct {
 		logger logger.Logger
-		*sql.DB
+		executor
+	}
+
+	rowScanner interface {
+		Scan(dest ...interface{}) error
 	}
 )
 
 func NewCatRepository(customLogger logger.Logger, r *sql.DB) *catRepository {
 	return &catRepository{
-		logger: customLogger,
-		DB:     r,
+		logger:   customLogger,
+		executor: executor{db: r},
 	}
 }
 
+// insertCatQuery hires the cat and opens its first employment period.
+const insertCatQuery = `
+	WITH c AS (
+		INSERT INTO cats(name, years_of_experience, breed, salary, rank) VALUES ($1, $2, $3, $4, $5) RETURNING ` + catColumns + `
+	), p AS (
+		INSERT INTO cat_employment_periods (cat_id, started_at) SELECT id, hired_at FROM c
+	)
+	SELECT ` + catColumns + ` FROM c;
+`
+
 func (r *catRepository) Add(cat models.Cat) (*models.Cat, error) {
-	query := "INSERT INTO cats(name, years_of_experience, breed, salary ) VALUES ($1, $2, $3, $4) RETURNING id, name, years_of_experience, breed, salary, created_at;"
+	query := insertCatQuery
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	row := r.QueryRowContext(ctx, query, cat.Name, cat.YearsOfExperience, cat.Breed, cat.Salary)
+	row := r.conn().QueryRowContext(ctx, query, cat.Name, cat.YearsOfExperience, cat.Breed, cat.Salary, cat.Rank)
 
 	var result models.Cat
 
-	err := row.Scan(
-		&result.ID,
-		&result.Name,
-		&result.YearsOfExperience,
-		&result.Breed,
-		&result.Salary,
-		&result.CreatedAt,
-	)
+	err := scanCat(row, &result)
 
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
@@ -50,36 +63,176 @@ func (r *catRepository) Add(cat models.Cat) (*models.Cat, error) {
 	return &result, nil
 }
 
-func (r *catRepository) Delete(id uint) error {
-	query := "DELETE FROM cats WHERE id = $1;"
+func (r *catRepository) AddMany(cats []models.Cat) ([]models.Cat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	list := make([]models.Cat, 0, len(cats))
+
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		stmt, err := conn.PrepareContext(ctx, insertCatQuery)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, cat := range cats {
+			var res models.Cat
+
+			if err := scanCat(stmt.QueryRowContext(ctx, cat.Name, cat.YearsOfExperience, cat.Breed, cat.Salary, cat.Rank), &res); err != nil {
+				return err
+			}
+
+			list = append(list, res)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func (r *catRepository) Terminate(id uint, reason string) (*models.Cat, error) {
+	query := `
+		WITH c AS (
+			UPDATE cats SET terminated_at = NOW(), termination_reason = $1 WHERE id = $2 RETURNING ` + catColumns + `
+		), p AS (
+			UPDATE cat_employment_periods SET ended_at = c.terminated_at FROM c
+			WHERE cat_employment_periods.cat_id = c.id AND cat_employment_periods.ended_at IS NULL
+		)
+		SELECT ` + catColumns + ` FROM c;
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	_, err := r.ExecContext(ctx, query, id)
+	row := r.conn().QueryRowContext(ctx, query, reason, id)
 
-	return err
+	var res models.Cat
+
+	err := scanCat(row, &res)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
 }
 
-func (r *catRepository) Update(id uint, salary float64) (*models.Cat, error) {
-	query := "UPDATE cats SET salary = $1 WHERE id = $2 RETURNING id, name, years_of_experience, breed, salary, created_at;"
+// Rehire opens a new employment period for a fired cat. hired_at keeps the
+// date of the first hire; the periods record every return.
+func (r *catRepository) Rehire(id uint) (*models.Cat, error) {
+	query := `
+		WITH c AS (
+			UPDATE cats SET terminated_at = NULL, termination_reason = '' WHERE id = $1 RETURNING ` + catColumns + `
+		), p AS (
+			INSERT INTO cat_employment_periods (cat_id, started_at) SELECT id, NOW() FROM c
+		)
+		SELECT ` + catColumns + ` FROM c;
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	row := r.QueryRowContext(ctx, query, salary, id)
+	row := r.conn().QueryRowContext(ctx, query, id)
 
-	var updatedCat models.Cat
+	var res models.Cat
+
+	err := scanCat(row, &res)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *catRepository) SetPhoto(id uint, key, contentType string) (*models.Cat, error) {
+	query := "UPDATE cats SET photo_key = $1, photo_content_type = $2 WHERE id = $3 RETURNING " + catColumns + ";"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
 
-	err := row.Scan(
-		&updatedCat.ID,
-		&updatedCat.Name,
-		&updatedCat.YearsOfExperience,
-		&updatedCat.Breed,
-		&updatedCat.Salary,
-		&updatedCat.CreatedAt,
+	row := r.conn().QueryRowContext(ctx, query, key, contentType, id)
+
+	var res models.Cat
+
+	err := scanCat(row, &res)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *catRepository) HasActiveMission(id uint) (bool, error) {
+	query := `
+		SELECT EXISTS (
+		    SELECT 1 FROM mission_members mm JOIN missions m ON m.id = mm.mission_id
+		    WHERE mm.cat_id = $1 AND mm.left_at IS NULL AND m.state IN ` + activeMissionStates + `
+		);
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var exists bool
+	if err := r.conn().QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return false, apperrors.ErrDatabase
+	}
+
+	return exists, nil
+}
+
+func (r *catRepository) Update(id uint, update models.CatUpdate) (*models.Cat, error) {
+	assignments := make([]string, 0)
+	args := make([]interface{}, 0)
+	addAssignment := func(column string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Name != nil {
+		addAssignment("name", *update.Name)
+	}
+	if update.YearsOfExperience != nil {
+		addAssignment("years_of_experience", *update.YearsOfExperience)
+	}
+	if update.Breed != nil {
+		addAssignment("breed", *update.Breed)
+	}
+	if update.Salary != nil {
+		addAssignment("salary", *update.Salary)
+	}
+	if update.Rank != nil {
+		addAssignment("rank", *update.Rank)
+	}
+
+	args = append(args, id)
+	query := fmt.Sprintf(
+		"UPDATE cats SET %s WHERE id = $%d RETURNING "+catColumns+";",
+		strings.Join(assignments, ", "), len(args),
 	)
 
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.conn().QueryRowContext(ctx, query, args...)
+
+	var updatedCat models.Cat
+
+	err := scanCat(row, &updatedCat)
+
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
 		return nil, apperrors.ErrDatabase
@@ -87,14 +240,108 @@ func (r *catRepository) Update(id uint, salary float64) (*models.Cat, error) {
 	return &updatedCat, nil
 }
 
-func (r *catRepository) List() ([]models.Cat, error) {
-	list := []models.Cat{}
-	query := "SELECT * FROM cats;"
+var catSortColumns = map[string]sortColumn{
+	"id":                  {name: "id", sqlType: "BIGINT"},
+	"name":                {name: "name", sqlType: "VARCHAR"},
+	"years_of_experience": {name: "years_of_experience", sqlType: "SMALLINT"},
+	"breed":               {name: "breed", sqlType: "VARCHAR"},
+	"salary":              {name: "salary", sqlType: "DECIMAL"},
+	"created_at":          {name: "created_at", sqlType: "TIMESTAMPTZ"},
+	"hired_at":            {name: "hired_at", sqlType: "TIMESTAMPTZ"},
+}
+
+func (r *catRepository) List(listQuery models.CatListQuery) (*models.CatPage, error) {
+	sortBy := listQuery.SortBy
+	if sortBy == "" {
+		sortBy = "id"
+	}
+
+	column, ok := catSortColumns[sortBy]
+	if !ok {
+		r.logger.Warnf(apperrors.ErrBadRequestMsg("Unknown sort field"))
+		return nil, apperrors.ErrBadRequestf("Unknown sort field")
+	}
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if !listQuery.IncludeTerminated {
+		conditions = append(conditions, "terminated_at IS NULL")
+	}
+	if listQuery.Breed != "" {
+		addCondition("breed = $%d", listQuery.Breed)
+	}
+	if listQuery.MinYearsOfExperience != nil {
+		addCondition("years_of_experience >= $%d", *listQuery.MinYearsOfExperience)
+	}
+	if listQuery.MaxYearsOfExperience != nil {
+		addCondition("years_of_experience <= $%d", *listQuery.MaxYearsOfExperience)
+	}
+	if listQuery.MinSalary != nil {
+		addCondition("salary >= $%d", *listQuery.MinSalary)
+	}
+	if listQuery.MaxSalary != nil {
+		addCondition("salary <= $%d", *listQuery.MaxSalary)
+	}
+	if listQuery.HiredAfter != nil {
+		addCondition("hired_at >= $%d", *listQuery.HiredAfter)
+	}
+	if listQuery.HiredBefore != nil {
+		addCondition("hired_at < $%d", *listQuery.HiredBefore)
+	}
+	if listQuery.AvailableOn != nil {
+		addCondition("NOT EXISTS (SELECT 1 FROM cat_leaves l WHERE l.cat_id = cats.id AND $%d::DATE BETWEEN l.start_date AND l.end_date)", *listQuery.AvailableOn)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	rows, err := r.QueryContext(ctx, query)
+	var page models.CatPage
+	page.List = []models.Cat{}
+
+	if listQuery.WithTotalCount {
+		countQuery := "SELECT COUNT(*) FROM cats" + whereClause(conditions) + ";"
+
+		var total uint
+		if err := r.conn().QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		page.TotalCount = &total
+	}
+
+	direction, comparison := "ASC", ">"
+	if listQuery.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	cursorKey := "cats:" + sortBy + ":" + strings.ToLower(direction)
+
+	if listQuery.Cursor != "" {
+		after, err := decodeCursor(listQuery.Cursor, cursorKey, column)
+		if err != nil {
+			r.logger.Warnf(apperrors.ErrBadRequestMsg(err.Error()))
+			return nil, apperrors.ErrBadRequestf("Invalid cursor")
+		}
+
+		args = append(args, after.Value, after.ID)
+		conditions = append(conditions, fmt.Sprintf(
+			"(%s, id) %s ($%d::%s, $%d::BIGINT)",
+			column.name, comparison, len(args)-1, column.sqlType, len(args),
+		))
+	}
+
+	args = append(args, listQuery.Limit+1)
+	query := fmt.Sprintf(
+		"SELECT %s FROM cats%s ORDER BY %s %s, id %s LIMIT $%d;",
+		catColumns, whereClause(conditions), column.name, direction, direction, len(args),
+	)
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
 
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
@@ -105,18 +352,11 @@ func (r *catRepository) List() ([]models.Cat, error) {
 
 	for rows.Next() {
 		var cat models.Cat
-		if err := rows.Scan(
-			&cat.ID,
-			&cat.Name,
-			&cat.YearsOfExperience,
-			&cat.Breed,
-			&cat.Salary,
-			&cat.CreatedAt,
-		); err != nil {
+		if err := scanCat(rows, &cat); err != nil {
 			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
 			return nil, apperrors.ErrDatabase
 		}
-		list = append(list, cat)
+		page.List = append(page.List, cat)
 	}
 
 	if err := rows.Close(); err != nil {
@@ -129,23 +369,52 @@ func (r *catRepository) List() ([]models.Cat, error) {
 		return nil, apperrors.ErrDatabase
 	}
 
-	return list, nil
+	if uint(len(page.List)) > listQuery.Limit {
+		page.List = page.List[:listQuery.Limit]
+		last := page.List[len(page.List)-1]
+		page.NextCursor = encodeCursor(cursorKey, catSortValue(last, sortBy), last.ID)
+	}
+
+	return &page, nil
+}
+
+func catSortValue(cat models.Cat, sortBy string) string {
+	switch sortBy {
+	case "name":
+		return cat.Name
+	case "years_of_experience":
+		return strconv.FormatUint(uint64(cat.YearsOfExperience), 10)
+	case "breed":
+		return cat.Breed
+	case "salary":
+		return strconv.FormatFloat(cat.Salary, 'f', -1, 64)
+	case "created_at":
+		return cat.CreatedAt.Format(time.RFC3339Nano)
+	case "hired_at":
+		return cat.HiredAt.Format(time.RFC3339Nano)
+	default:
+		return strconv.FormatUint(uint64(cat.ID), 10)
+	}
 }
 
 func (r *catRepository) Get(id uint) (*models.Cat, error) {
+	return r.get("SELECT "+catColumns+" FROM cats WHERE id = $1;", id)
+}
+
+// GetForUpdate reads the cat and, inside a transaction, locks its row until commit.
+func (r *catRepository) GetForUpdate(id uint) (*models.Cat, error) {
+	return r.get("SELECT "+catColumns+" FROM cats WHERE id = $1 FOR UPDATE;", id)
+}
+
+func (r *catRepository) get(query string, id uint) (*models.Cat, error) {
 	var res models.Cat
-	query := "SELECT * FROM cats WHERE id = $1;"
 
-	row := r.QueryRow(query, id)
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
 
-	err := row.Scan(
-		&res.ID,
-		&res.Name,
-		&res.YearsOfExperience,
-		&res.Breed,
-		&res.Salary,
-		&res.CreatedAt,
-	)
+	row := r.conn().QueryRowContext(ctx, query, id)
+
+	err := scanCat(row, &res)
 
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
@@ -160,3 +429,91 @@ func (r *catRepository) Get(id uint) (*models.Cat, error) {
 	return &res, nil
 
 }
+
+// ListEmployedBetween returns cats with an employment period that overlaps [start, end].
+func (r *catRepository) ListEmployedBetween(start, end time.Time) ([]models.Cat, error) {
+	list := []models.Cat{}
+	query := "SELECT " + catColumns + " FROM cats WHERE EXISTS (SELECT 1 FROM cat_employment_periods p WHERE p.cat_id = cats.id AND " + periodOverlaps + ") ORDER BY id;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, start, end)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var cat models.Cat
+		if err := scanCat(rows, &cat); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, cat)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+// periodOverlaps matches employment periods p that share a day with [$1, $2].
+const periodOverlaps = "p.started_at::DATE <= $2::DATE AND (p.ended_at IS NULL OR p.ended_at::DATE >= $1::DATE)"
+
+// ListEmploymentPeriods returns every employment period that overlaps [start, end].
+func (r *catRepository) ListEmploymentPeriods(start, end time.Time) ([]models.EmploymentPeriod, error) {
+	list := []models.EmploymentPeriod{}
+	query := "SELECT p.cat_id, p.started_at, p.ended_at FROM cat_employment_periods p WHERE " + periodOverlaps + " ORDER BY p.cat_id, p.started_at;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, start, end)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var period models.EmploymentPeriod
+		if err := rows.Scan(&period.CatID, &period.StartedAt, &period.EndedAt); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func scanCat(row rowScanner, cat *models.Cat) error {
+	return row.Scan(
+		&cat.ID,
+		&cat.Name,
+		&cat.YearsOfExperience,
+		&cat.Breed,
+		&cat.Salary,
+		&cat.Rank,
+		&cat.CreatedAt,
+		&cat.HiredAt,
+		&cat.TerminatedAt,
+		&cat.TerminationReason,
+		&cat.PhotoKey,
+		&cat.PhotoContentType,
+	)
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func (r *catRepository) ListStats(catIDs []uint) ([]models.CatStats, error) {
+	list := []models.CatStats{}
+
+	ids := make([]int64, 0, len(catIDs))
+	for _, id := range catIDs {
+		ids = append(ids, int64(id))
+	}
+
+	// Missions count for every cat still on the team when they closed, in any
+	// role; targets count for the cat that completed them.
+	query := `
+		SELECT
+		    c.id,
+		    (SELECT COUNT(*) FROM missions m JOIN mission_members mm ON mm.mission_id = m.id
+		        WHERE mm.cat_id = c.id AND mm.left_at IS NULL AND m.is_completed),
+		    (SELECT COUNT(*) FROM targets t WHERE t.completed_by = c.id AND t.is_completed),
+		    (SELECT percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM t.completed_at - t.created_at))
+		        FROM targets t
+		        WHERE t.completed_by = c.id AND t.completed_at IS NOT NULL),
+		    (SELECT COALESCE(array_agg(DISTINCT t.country ORDER BY t.country), '{}')
+		        FROM targets t
+		        WHERE EXISTS (SELECT 1 FROM mission_members mm WHERE mm.mission_id = t.mission_id AND mm.cat_id = c.id)),
+		    cm.id,
+		    cm.name
+		FROM cats c
+		LEFT JOIN LATERAL (
+		    SELECT m.id, m.name FROM missions m JOIN mission_members mm ON mm.mission_id = m.id
+		    WHERE mm.cat_id = c.id AND mm.left_at IS NULL AND m.state IN ` + activeMissionStates + `
+		    ORDER BY m.id
+		    LIMIT 1
+		) cm ON TRUE
+		WHERE c.id = ANY($1)
+		ORDER BY c.id;
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, pq.Array(ids))
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var stats models.CatStats
+		var medianSeconds *float64
+		var currentMissionID *uint
+		var currentMissionName *string
+
+		if err := rows.Scan(
+			&stats.CatID,
+			&stats.MissionsCompleted,
+			&stats.TargetsCompleted,
+			&medianSeconds,
+			pq.Array(&stats.Countries),
+			&currentMissionID,
+			&currentMissionName,
+		); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		if medianSeconds != nil {
+			median := time.Duration(*medianSeconds * float64(time.Second))
+			stats.MedianTargetCompletion = &median
+		}
+
+		if currentMissionID != nil && currentMissionName != nil {
+			stats.CurrentMission = &models.MissionRef{ID: *currentMissionID, Name: *currentMissionName}
+		}
+
+		list = append(list, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
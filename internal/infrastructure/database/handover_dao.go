@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+
+	"github.com/lib/pq"
+)
+
+const handoverColumns = "id, mission_id, from_cat_id, to_cat_id, from_assigned_at, reason, actor, created_at"
+
+// AddHandover stores the handover together with a snapshot of the mission's
+// target notes as the outgoing cat left them.
+func (r *missionRepository) AddHandover(handover models.Handover) (*models.Handover, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res models.Handover
+	res.Notes = make([]models.HandoverNote, 0)
+
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := "INSERT INTO mission_handovers (mission_id, from_cat_id, to_cat_id, from_assigned_at, reason, actor) VALUES ($1, $2, $3, $4, $5, $6) RETURNING " + handoverColumns + ";"
+
+		row := conn.QueryRowContext(ctx, query, handover.MissionID, handover.FromCatID, handover.ToCatID, handover.FromAssignedAt, handover.Reason, handover.Actor)
+		if err := scanHandover(row, &res); err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO mission_handover_notes (handover_id, target_id, target_name, notes)
+			SELECT $1, id, name, notes FROM targets WHERE mission_id = $2 ORDER BY id
+			RETURNING target_id, target_name, notes;
+		`
+
+		rows, err := conn.QueryContext(ctx, query, res.ID, handover.MissionID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var note models.HandoverNote
+			if err := rows.Scan(&note.TargetID, &note.TargetName, &note.Notes); err != nil {
+				return err
+			}
+			res.Notes = append(res.Notes, note)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *missionRepository) ListHandovers(missionID uint) ([]models.Handover, error) {
+	list := []models.Handover{}
+	query := "SELECT " + handoverColumns + " FROM mission_handovers WHERE mission_id = $1 ORDER BY created_at, id;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, missionID)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	index := make(map[uint]int)
+
+	for rows.Next() {
+		var handover models.Handover
+		if err := scanHandover(rows, &handover); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		handover.Notes = make([]models.HandoverNote, 0)
+
+		index[handover.ID] = len(list)
+		ids = append(ids, int64(handover.ID))
+		list = append(list, handover)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	if len(list) == 0 {
+		return list, nil
+	}
+
+	query = "SELECT handover_id, target_id, target_name, notes FROM mission_handover_notes WHERE handover_id = ANY($1) ORDER BY target_id;"
+
+	noteRows, err := r.conn().QueryContext(ctx, query, pq.Array(ids))
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer noteRows.Close()
+
+	for noteRows.Next() {
+		var handoverID uint
+		var note models.HandoverNote
+
+		if err := noteRows.Scan(&handoverID, &note.TargetID, &note.TargetName, &note.Notes); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		handover := &list[index[handoverID]]
+		handover.Notes = append(handover.Notes, note)
+	}
+
+	if err := noteRows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func scanHandover(row rowScanner, handover *models.Handover) error {
+	return row.Scan(
+		&handover.ID,
+		&handover.MissionID,
+		&handover.FromCatID,
+		&handover.ToCatID,
+		&handover.FromAssignedAt,
+		&handover.Reason,
+		&handover.Actor,
+		&handover.CreatedAt,
+	)
+}
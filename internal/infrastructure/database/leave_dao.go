@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"time"
+)
+
+const leaveColumns = "id, cat_id, start_date, end_date, reason, created_at"
+
+func (r *catRepository) AddLeave(leave models.Leave) (*models.Leave, error) {
+	query := "INSERT INTO cat_leaves (cat_id, start_date, end_date, reason) VALUES ($1, $2, $3, $4) RETURNING " + leaveColumns + ";"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.conn().QueryRowContext(ctx, query, leave.CatID, leave.StartDate, leave.EndDate, leave.Reason)
+
+	var res models.Leave
+
+	if err := scanLeave(row, &res); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *catRepository) GetLeave(id uint) (*models.Leave, error) {
+	query := "SELECT " + leaveColumns + " FROM cat_leaves WHERE id = $1;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.conn().QueryRowContext(ctx, query, id)
+
+	var res models.Leave
+
+	if err := scanLeave(row, &res); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *catRepository) DeleteLeave(id uint) error {
+	query := "DELETE FROM cat_leaves WHERE id = $1;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, id)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (r *catRepository) ListLeaves(catID uint) ([]models.Leave, error) {
+	query := "SELECT " + leaveColumns + " FROM cat_leaves WHERE cat_id = $1 ORDER BY start_date, id;"
+
+	return r.queryLeaves(query, catID)
+}
+
+// ListOverlappingLeaves returns leaves of the cat that intersect [from, to]. A nil to means the window is open-ended.
+func (r *catRepository) ListOverlappingLeaves(catID uint, from time.Time, to *time.Time) ([]models.Leave, error) {
+	query := "SELECT " + leaveColumns + " FROM cat_leaves WHERE cat_id = $1 AND end_date >= $2::DATE AND ($3::DATE IS NULL OR start_date <= $3::DATE) ORDER BY start_date, id;"
+
+	return r.queryLeaves(query, catID, from, to)
+}
+
+func (r *catRepository) queryLeaves(query string, args ...interface{}) ([]models.Leave, error) {
+	list := []models.Leave{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var leave models.Leave
+		if err := scanLeave(rows, &leave); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, leave)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func scanLeave(row rowScanner, leave *models.Leave) error {
+	return row.Scan(
+		&leave.ID,
+		&leave.CatID,
+		&leave.StartDate,
+		&leave.EndDate,
+		&leave.Reason,
+		&leave.CreatedAt,
+	)
+}
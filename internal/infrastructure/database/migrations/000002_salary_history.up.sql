@@ -0,0 +1,16 @@
+CREATE TABLE "salary_history" (
+"id" BIGSERIAL PRIMARY KEY,
+"cat_id" BIGINT NOT NULL,
+"old_salary" DECIMAL DEFAULT NULL,
+"new_salary" DECIMAL NOT NULL,
+"reason" VARCHAR NOT NULL DEFAULT '',
+"effective_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW()),
+"applied_at" TIMESTAMPTZ DEFAULT NULL,
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+ALTER TABLE "salary_history" ADD FOREIGN KEY ("cat_id") REFERENCES "cats" ("id");
+
+CREATE INDEX ON "salary_history" ("cat_id", "effective_at");
+
+CREATE INDEX ON "salary_history" ("effective_at") WHERE "applied_at" IS NULL;
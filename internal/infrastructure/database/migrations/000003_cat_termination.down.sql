@@ -0,0 +1,3 @@
+ALTER TABLE "cats" DROP COLUMN IF EXISTS "termination_reason";
+ALTER TABLE "cats" DROP COLUMN IF EXISTS "terminated_at";
+ALTER TABLE "cats" DROP COLUMN IF EXISTS "hired_at";
@@ -0,0 +1,7 @@
+ALTER TABLE "cats" ADD COLUMN "hired_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW());
+ALTER TABLE "cats" ADD COLUMN "terminated_at" TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE "cats" ADD COLUMN "termination_reason" VARCHAR NOT NULL DEFAULT '';
+
+UPDATE "cats" SET "hired_at" = "created_at";
+
+CREATE INDEX ON "cats" ("terminated_at");
@@ -0,0 +1,6 @@
+CREATE TABLE "breeds" (
+"id" VARCHAR PRIMARY KEY,
+"name" VARCHAR NOT NULL UNIQUE,
+"origin" VARCHAR NOT NULL DEFAULT '',
+"updated_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
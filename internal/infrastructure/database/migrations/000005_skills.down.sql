@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "mission_skill_requirements";
+DROP TABLE IF EXISTS "cat_skills";
+DROP TABLE IF EXISTS "skills";
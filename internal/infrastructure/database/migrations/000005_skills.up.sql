@@ -0,0 +1,32 @@
+CREATE TABLE "skills" (
+"id" BIGSERIAL PRIMARY KEY,
+"name" VARCHAR NOT NULL UNIQUE,
+"description" VARCHAR NOT NULL DEFAULT '',
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "cat_skills" (
+"cat_id" BIGINT NOT NULL,
+"skill_id" BIGINT NOT NULL,
+"level" SMALLINT NOT NULL CHECK ("level" BETWEEN 1 AND 5),
+"updated_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW()),
+PRIMARY KEY ("cat_id", "skill_id")
+);
+
+CREATE TABLE "mission_skill_requirements" (
+"mission_id" BIGINT NOT NULL,
+"skill_id" BIGINT NOT NULL,
+"min_level" SMALLINT NOT NULL CHECK ("min_level" BETWEEN 1 AND 5),
+PRIMARY KEY ("mission_id", "skill_id")
+);
+
+ALTER TABLE "cat_skills" ADD FOREIGN KEY ("cat_id") REFERENCES "cats" ("id");
+ALTER TABLE "cat_skills" ADD FOREIGN KEY ("skill_id") REFERENCES "skills" ("id");
+
+ALTER TABLE "mission_skill_requirements" ADD FOREIGN KEY ("mission_id") REFERENCES "missions" ("id") ON DELETE CASCADE;
+ALTER TABLE "mission_skill_requirements" ADD FOREIGN KEY ("skill_id") REFERENCES "skills" ("id");
+
+INSERT INTO "skills" ("name", "description") VALUES
+('infiltration', 'Entering guarded premises unnoticed'),
+('surveillance', 'Observing targets over long periods'),
+('languages', 'Communicating with foreign contacts');
@@ -0,0 +1,13 @@
+CREATE TABLE "cat_leaves" (
+"id" BIGSERIAL PRIMARY KEY,
+"cat_id" BIGINT NOT NULL,
+"start_date" DATE NOT NULL,
+"end_date" DATE NOT NULL,
+"reason" VARCHAR NOT NULL DEFAULT '',
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW()),
+CHECK ("end_date" >= "start_date")
+);
+
+ALTER TABLE "cat_leaves" ADD FOREIGN KEY ("cat_id") REFERENCES "cats" ("id");
+
+CREATE INDEX ON "cat_leaves" ("cat_id", "start_date", "end_date");
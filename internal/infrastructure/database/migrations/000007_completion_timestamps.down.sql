@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "targets_mission_id_idx";
+DROP INDEX IF EXISTS "missions_cat_id_idx";
+
+ALTER TABLE "targets" DROP COLUMN IF EXISTS "completed_at";
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "completed_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "missions" ADD COLUMN "completed_at" TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE "targets" ADD COLUMN "completed_at" TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX ON "missions" ("cat_id");
+CREATE INDEX ON "targets" ("mission_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "cats" DROP COLUMN IF EXISTS "rank";
+
+DROP TABLE IF EXISTS "ranks";
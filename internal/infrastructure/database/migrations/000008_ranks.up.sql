@@ -0,0 +1,20 @@
+CREATE TABLE "ranks" (
+"code" VARCHAR PRIMARY KEY,
+"name" VARCHAR NOT NULL,
+"position" SMALLINT NOT NULL UNIQUE,
+"min_years_of_experience" SMALLINT NOT NULL DEFAULT 0,
+"min_completed_missions" INTEGER NOT NULL DEFAULT 0,
+"min_salary" DECIMAL NOT NULL,
+"max_salary" DECIMAL NOT NULL,
+CHECK ("max_salary" >= "min_salary")
+);
+
+INSERT INTO "ranks" ("code", "name", "position", "min_years_of_experience", "min_completed_missions", "min_salary", "max_salary") VALUES
+('recruit', 'Recruit', 1, 0, 0, 0, 3000),
+('agent', 'Agent', 2, 2, 3, 2000, 6000),
+('senior_agent', 'Senior Agent', 3, 5, 10, 5000, 10000),
+('handler', 'Handler', 4, 8, 20, 8000, 20000);
+
+ALTER TABLE "cats" ADD COLUMN "rank" VARCHAR NOT NULL DEFAULT 'recruit';
+
+ALTER TABLE "cats" ADD FOREIGN KEY ("rank") REFERENCES "ranks" ("code");
@@ -0,0 +1,37 @@
+CREATE TABLE "payroll_runs" (
+"id" BIGSERIAL PRIMARY KEY,
+"period_start" DATE NOT NULL,
+"period_end" DATE NOT NULL,
+"total" DECIMAL NOT NULL,
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW()),
+UNIQUE ("period_start", "period_end"),
+CHECK ("period_end" >= "period_start")
+);
+
+CREATE TABLE "payroll_lines" (
+"id" BIGSERIAL PRIMARY KEY,
+"run_id" BIGINT NOT NULL,
+"cat_id" BIGINT NOT NULL,
+"cat_name" VARCHAR NOT NULL,
+"salary" DECIMAL NOT NULL,
+"days_employed" SMALLINT NOT NULL,
+"days_in_period" SMALLINT NOT NULL,
+"amount" DECIMAL NOT NULL
+);
+
+ALTER TABLE "payroll_lines" ADD FOREIGN KEY ("run_id") REFERENCES "payroll_runs" ("id");
+ALTER TABLE "payroll_lines" ADD FOREIGN KEY ("cat_id") REFERENCES "cats" ("id");
+
+CREATE INDEX ON "payroll_lines" ("run_id");
+
+CREATE FUNCTION "payroll_immutable"() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'payroll records are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "payroll_runs_immutable" BEFORE UPDATE OR DELETE ON "payroll_runs"
+FOR EACH ROW EXECUTE PROCEDURE "payroll_immutable"();
+
+CREATE TRIGGER "payroll_lines_immutable" BEFORE UPDATE OR DELETE ON "payroll_lines"
+FOR EACH ROW EXECUTE PROCEDURE "payroll_immutable"();
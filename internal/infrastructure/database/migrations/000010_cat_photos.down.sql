@@ -0,0 +1,2 @@
+ALTER TABLE "cats" DROP COLUMN IF EXISTS "photo_content_type";
+ALTER TABLE "cats" DROP COLUMN IF EXISTS "photo_key";
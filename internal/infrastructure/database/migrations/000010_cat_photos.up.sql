@@ -0,0 +1,2 @@
+ALTER TABLE "cats" ADD COLUMN "photo_key" VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE "cats" ADD COLUMN "photo_content_type" VARCHAR NOT NULL DEFAULT '';
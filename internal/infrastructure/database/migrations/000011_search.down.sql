@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "targets_name_trgm_idx";
+DROP INDEX IF EXISTS "missions_name_trgm_idx";
+DROP INDEX IF EXISTS "cats_name_trgm_idx";
+
+DROP INDEX IF EXISTS "targets_search_vector_idx";
+DROP INDEX IF EXISTS "missions_search_vector_idx";
+DROP INDEX IF EXISTS "cats_search_vector_idx";
+
+ALTER TABLE "targets" DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE "cats" DROP COLUMN IF EXISTS "search_vector";
+
+DROP EXTENSION IF EXISTS "pg_trgm";
@@ -0,0 +1,24 @@
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+ALTER TABLE "cats" ADD COLUMN "search_vector" TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', "name"), 'A') ||
+    setweight(to_tsvector('english', "breed"), 'B')
+) STORED;
+
+ALTER TABLE "missions" ADD COLUMN "search_vector" TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', "name"), 'A')
+) STORED;
+
+ALTER TABLE "targets" ADD COLUMN "search_vector" TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', "name"), 'A') ||
+    setweight(to_tsvector('english', "country"), 'B') ||
+    setweight(to_tsvector('english', "notes"), 'C')
+) STORED;
+
+CREATE INDEX "cats_search_vector_idx" ON "cats" USING GIN ("search_vector");
+CREATE INDEX "missions_search_vector_idx" ON "missions" USING GIN ("search_vector");
+CREATE INDEX "targets_search_vector_idx" ON "targets" USING GIN ("search_vector");
+
+CREATE INDEX "cats_name_trgm_idx" ON "cats" USING GIN ("name" gin_trgm_ops);
+CREATE INDEX "missions_name_trgm_idx" ON "missions" USING GIN ("name" gin_trgm_ops);
+CREATE INDEX "targets_name_trgm_idx" ON "targets" USING GIN ("name" gin_trgm_ops);
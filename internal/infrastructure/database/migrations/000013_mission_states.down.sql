@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "mission_transitions";
+
+DROP INDEX IF EXISTS "missions_active_cat_id_key";
+ALTER TABLE "missions" DROP COLUMN "is_completed";
+ALTER TABLE "missions" ADD COLUMN "is_completed" BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE "missions" SET "is_completed" = TRUE WHERE "state" = 'completed';
+
+CREATE UNIQUE INDEX "missions_active_cat_id_key" ON "missions" ("cat_id") WHERE "cat_id" IS NOT NULL AND "is_completed" = FALSE;
+
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "closed_at";
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "state";
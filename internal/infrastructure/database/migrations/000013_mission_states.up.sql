@@ -0,0 +1,26 @@
+ALTER TABLE "missions" ADD COLUMN "state" VARCHAR NOT NULL DEFAULT 'draft'
+    CHECK ("state" IN ('draft', 'assigned', 'in_progress', 'completed', 'aborted', 'failed'));
+ALTER TABLE "missions" ADD COLUMN "closed_at" TIMESTAMPTZ DEFAULT NULL;
+
+UPDATE "missions" SET "state" = 'completed', "closed_at" = "completed_at" WHERE "is_completed";
+UPDATE "missions" SET "state" = 'assigned' WHERE NOT "is_completed" AND "cat_id" IS NOT NULL;
+
+DROP INDEX IF EXISTS "missions_active_cat_id_key";
+ALTER TABLE "missions" DROP COLUMN "is_completed";
+ALTER TABLE "missions" ADD COLUMN "is_completed" BOOLEAN GENERATED ALWAYS AS ("state" = 'completed') STORED;
+
+CREATE UNIQUE INDEX "missions_active_cat_id_key" ON "missions" ("cat_id") WHERE "state" IN ('assigned', 'in_progress');
+
+CREATE TABLE "mission_transitions" (
+"id" BIGSERIAL PRIMARY KEY,
+"mission_id" BIGINT NOT NULL,
+"from_state" VARCHAR NOT NULL,
+"to_state" VARCHAR NOT NULL,
+"actor" VARCHAR NOT NULL,
+"reason" VARCHAR NOT NULL DEFAULT '',
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+ALTER TABLE "mission_transitions" ADD FOREIGN KEY ("mission_id") REFERENCES "missions" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "mission_transitions" ("mission_id");
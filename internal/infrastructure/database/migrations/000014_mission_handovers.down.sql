@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "mission_handover_notes";
+DROP TABLE IF EXISTS "mission_handovers";
+
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "assigned_at";
@@ -0,0 +1,28 @@
+ALTER TABLE "missions" ADD COLUMN "assigned_at" TIMESTAMPTZ DEFAULT NULL;
+
+UPDATE "missions" SET "assigned_at" = "created_at" WHERE "cat_id" IS NOT NULL;
+
+CREATE TABLE "mission_handovers" (
+"id" BIGSERIAL PRIMARY KEY,
+"mission_id" BIGINT NOT NULL,
+"from_cat_id" BIGINT NOT NULL,
+"to_cat_id" BIGINT DEFAULT NULL,
+"from_assigned_at" TIMESTAMPTZ DEFAULT NULL,
+"reason" VARCHAR NOT NULL,
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "mission_handover_notes" (
+"handover_id" BIGINT NOT NULL,
+"target_id" BIGINT NOT NULL,
+"target_name" VARCHAR NOT NULL,
+"notes" VARCHAR NOT NULL,
+PRIMARY KEY ("handover_id", "target_id")
+);
+
+ALTER TABLE "mission_handovers" ADD FOREIGN KEY ("mission_id") REFERENCES "missions" ("id") ON DELETE CASCADE;
+ALTER TABLE "mission_handovers" ADD FOREIGN KEY ("from_cat_id") REFERENCES "cats" ("id");
+ALTER TABLE "mission_handovers" ADD FOREIGN KEY ("to_cat_id") REFERENCES "cats" ("id");
+ALTER TABLE "mission_handover_notes" ADD FOREIGN KEY ("handover_id") REFERENCES "mission_handovers" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "mission_handovers" ("mission_id");
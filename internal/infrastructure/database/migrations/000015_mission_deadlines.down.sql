@@ -0,0 +1,3 @@
+ALTER TABLE "targets" DROP COLUMN IF EXISTS "due_at";
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "overdue_at";
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "due_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "missions" ADD COLUMN "due_at" TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE "missions" ADD COLUMN "overdue_at" TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE "targets" ADD COLUMN "due_at" TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX ON "missions" ("due_at") WHERE "due_at" IS NOT NULL;
@@ -0,0 +1,19 @@
+CREATE TABLE "mission_templates" (
+"id" BIGSERIAL PRIMARY KEY,
+"name" VARCHAR NOT NULL UNIQUE,
+"name_pattern" VARCHAR NOT NULL,
+"notes" VARCHAR NOT NULL DEFAULT '',
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW()),
+"updated_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "mission_template_targets" (
+"template_id" BIGINT NOT NULL,
+"position" SMALLINT NOT NULL,
+"name" VARCHAR NOT NULL,
+"country" VARCHAR NOT NULL,
+"notes" VARCHAR NOT NULL DEFAULT '',
+PRIMARY KEY ("template_id", "position")
+);
+
+ALTER TABLE "mission_template_targets" ADD FOREIGN KEY ("template_id") REFERENCES "mission_templates" ("id") ON DELETE CASCADE;
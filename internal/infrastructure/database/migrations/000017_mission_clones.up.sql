@@ -0,0 +1,5 @@
+ALTER TABLE "missions" ADD COLUMN "cloned_from_id" BIGINT DEFAULT NULL;
+
+ALTER TABLE "missions" ADD FOREIGN KEY ("cloned_from_id") REFERENCES "missions" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "missions" ("cloned_from_id");
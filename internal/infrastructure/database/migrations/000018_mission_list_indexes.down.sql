@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "targets_country_idx";
+DROP INDEX IF EXISTS "missions_created_at_id_idx";
@@ -0,0 +1,2 @@
+CREATE INDEX "missions_created_at_id_idx" ON "missions" ("created_at" DESC, "id" DESC);
+CREATE INDEX "targets_country_idx" ON "targets" (LOWER("country"));
@@ -0,0 +1,5 @@
+ALTER TABLE "mission_templates" DROP COLUMN IF EXISTS "mission_type";
+
+ALTER TABLE "missions" DROP COLUMN IF EXISTS "type";
+
+DROP TABLE IF EXISTS "mission_types";
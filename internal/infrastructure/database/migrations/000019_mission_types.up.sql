@@ -0,0 +1,20 @@
+CREATE TABLE "mission_types" (
+"code" VARCHAR PRIMARY KEY,
+"name" VARCHAR NOT NULL,
+"min_targets" SMALLINT NOT NULL,
+"max_targets" SMALLINT NOT NULL,
+"allow_delete_completed_targets" BOOLEAN NOT NULL DEFAULT FALSE,
+"allow_delete_assigned" BOOLEAN NOT NULL DEFAULT FALSE,
+CHECK ("min_targets" >= 1),
+CHECK ("max_targets" >= "min_targets")
+);
+
+INSERT INTO "mission_types" ("code", "name", "min_targets", "max_targets", "allow_delete_completed_targets", "allow_delete_assigned") VALUES
+('standard', 'Standard', 1, 3, FALSE, FALSE),
+('deep_cover', 'Deep Cover', 1, 10, FALSE, FALSE);
+
+ALTER TABLE "missions" ADD COLUMN "type" VARCHAR NOT NULL DEFAULT 'standard';
+ALTER TABLE "mission_templates" ADD COLUMN "mission_type" VARCHAR NOT NULL DEFAULT 'standard';
+
+ALTER TABLE "missions" ADD FOREIGN KEY ("type") REFERENCES "mission_types" ("code");
+ALTER TABLE "mission_templates" ADD FOREIGN KEY ("mission_type") REFERENCES "mission_types" ("code");
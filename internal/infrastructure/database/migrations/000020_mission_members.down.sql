@@ -0,0 +1,3 @@
+ALTER TABLE "targets" DROP COLUMN IF EXISTS "completed_by";
+
+DROP TABLE IF EXISTS "mission_members";
@@ -0,0 +1,20 @@
+CREATE TABLE "mission_members" (
+"mission_id" BIGINT NOT NULL,
+"cat_id" BIGINT NOT NULL,
+"role" VARCHAR NOT NULL CHECK ("role" IN ('lead', 'support', 'observer')),
+"joined_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW()),
+PRIMARY KEY ("mission_id", "cat_id")
+);
+
+ALTER TABLE "mission_members" ADD FOREIGN KEY ("mission_id") REFERENCES "missions" ("id") ON DELETE CASCADE;
+ALTER TABLE "mission_members" ADD FOREIGN KEY ("cat_id") REFERENCES "cats" ("id");
+
+CREATE UNIQUE INDEX "mission_members_lead_key" ON "mission_members" ("mission_id") WHERE "role" = 'lead';
+CREATE INDEX ON "mission_members" ("cat_id");
+
+INSERT INTO "mission_members" ("mission_id", "cat_id", "role", "joined_at")
+SELECT "id", "cat_id", 'lead', COALESCE("assigned_at", "created_at") FROM "missions" WHERE "cat_id" IS NOT NULL;
+
+ALTER TABLE "targets" ADD COLUMN "completed_by" BIGINT DEFAULT NULL;
+
+ALTER TABLE "targets" ADD FOREIGN KEY ("completed_by") REFERENCES "cats" ("id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "mission_expenses";
+
+DROP TABLE IF EXISTS "mission_budgets";
@@ -0,0 +1,22 @@
+CREATE TABLE "mission_budgets" (
+"mission_id" BIGINT PRIMARY KEY,
+"amount" DECIMAL NOT NULL CHECK ("amount" > 0),
+"policy" VARCHAR NOT NULL DEFAULT 'warn' CHECK ("policy" IN ('warn', 'block')),
+"updated_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+ALTER TABLE "mission_budgets" ADD FOREIGN KEY ("mission_id") REFERENCES "missions" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "mission_expenses" (
+"id" BIGSERIAL PRIMARY KEY,
+"mission_id" BIGINT NOT NULL,
+"amount" DECIMAL NOT NULL CHECK ("amount" > 0),
+"category" VARCHAR NOT NULL CHECK ("category" IN ('travel', 'lodging', 'equipment', 'informants', 'other')),
+"spent_on" DATE NOT NULL,
+"receipt_ref" VARCHAR NOT NULL DEFAULT '',
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+ALTER TABLE "mission_expenses" ADD FOREIGN KEY ("mission_id") REFERENCES "missions" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "mission_expenses" ("mission_id", "spent_on");
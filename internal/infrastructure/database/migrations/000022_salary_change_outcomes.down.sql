@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS "salary_change_outcomes_immutable" ON "salary_change_outcomes";
+DROP TRIGGER IF EXISTS "salary_history_immutable" ON "salary_history";
+DROP FUNCTION IF EXISTS "salary_ledger_immutable"();
+
+DROP INDEX IF EXISTS "salary_history_effective_at_idx";
+
+ALTER TABLE "salary_history" ADD COLUMN IF NOT EXISTS "old_salary" DECIMAL DEFAULT NULL;
+ALTER TABLE "salary_history" ADD COLUMN IF NOT EXISTS "applied_at" TIMESTAMPTZ DEFAULT NULL;
+
+UPDATE "salary_history" h SET "old_salary" = o."old_salary", "applied_at" = o."created_at"
+FROM "salary_change_outcomes" o WHERE o."change_id" = h."id" AND o."outcome" = 'applied';
+
+CREATE INDEX ON "salary_history" ("effective_at") WHERE "applied_at" IS NULL;
+
+DROP TABLE IF EXISTS "salary_change_outcomes";
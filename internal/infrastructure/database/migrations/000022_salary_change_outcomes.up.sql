@@ -0,0 +1,29 @@
+CREATE TABLE "salary_change_outcomes" (
+"change_id" BIGINT PRIMARY KEY,
+"outcome" VARCHAR NOT NULL CHECK ("outcome" IN ('applied', 'cancelled')),
+"old_salary" DECIMAL DEFAULT NULL,
+"note" VARCHAR NOT NULL DEFAULT '',
+"created_at" TIMESTAMPTZ NOT NULL DEFAULT (NOW())
+);
+
+ALTER TABLE "salary_change_outcomes" ADD FOREIGN KEY ("change_id") REFERENCES "salary_history" ("id");
+
+INSERT INTO "salary_change_outcomes" ("change_id", "outcome", "old_salary", "created_at")
+SELECT "id", 'applied', "old_salary", "applied_at" FROM "salary_history" WHERE "applied_at" IS NOT NULL;
+
+ALTER TABLE "salary_history" DROP COLUMN "old_salary";
+ALTER TABLE "salary_history" DROP COLUMN "applied_at";
+
+CREATE INDEX ON "salary_history" ("effective_at");
+
+CREATE FUNCTION "salary_ledger_immutable"() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'salary ledger records are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "salary_history_immutable" BEFORE UPDATE OR DELETE ON "salary_history"
+FOR EACH ROW EXECUTE PROCEDURE "salary_ledger_immutable"();
+
+CREATE TRIGGER "salary_change_outcomes_immutable" BEFORE UPDATE OR DELETE ON "salary_change_outcomes"
+FOR EACH ROW EXECUTE PROCEDURE "salary_ledger_immutable"();
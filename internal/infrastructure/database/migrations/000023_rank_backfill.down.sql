@@ -0,0 +1,2 @@
+-- Ranks picked from salary are kept: the rank each cat held before is not recorded.
+SELECT 1;
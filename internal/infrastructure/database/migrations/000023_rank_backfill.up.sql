@@ -0,0 +1,13 @@
+UPDATE "cats" c SET "rank" = (
+    SELECT r."code" FROM "ranks" r
+    WHERE c."salary" BETWEEN r."min_salary" AND r."max_salary"
+    ORDER BY r."position"
+    LIMIT 1
+)
+WHERE NOT EXISTS (
+    SELECT 1 FROM "ranks" r
+    WHERE r."code" = c."rank" AND c."salary" BETWEEN r."min_salary" AND r."max_salary"
+) AND EXISTS (
+    SELECT 1 FROM "ranks" r
+    WHERE c."salary" BETWEEN r."min_salary" AND r."max_salary"
+);
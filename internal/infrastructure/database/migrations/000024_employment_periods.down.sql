@@ -0,0 +1,3 @@
+ALTER TABLE "payroll_runs" DROP CONSTRAINT IF EXISTS "payroll_runs_period_excl";
+
+DROP TABLE IF EXISTS "cat_employment_periods";
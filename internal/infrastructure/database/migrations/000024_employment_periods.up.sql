@@ -0,0 +1,18 @@
+CREATE TABLE "cat_employment_periods" (
+"id" BIGSERIAL PRIMARY KEY,
+"cat_id" BIGINT NOT NULL,
+"started_at" TIMESTAMPTZ NOT NULL,
+"ended_at" TIMESTAMPTZ DEFAULT NULL,
+CHECK ("ended_at" IS NULL OR "ended_at" >= "started_at")
+);
+
+ALTER TABLE "cat_employment_periods" ADD FOREIGN KEY ("cat_id") REFERENCES "cats" ("id");
+
+CREATE UNIQUE INDEX "cat_employment_periods_open_key" ON "cat_employment_periods" ("cat_id") WHERE "ended_at" IS NULL;
+CREATE INDEX ON "cat_employment_periods" ("started_at", "ended_at");
+
+INSERT INTO "cat_employment_periods" ("cat_id", "started_at", "ended_at")
+SELECT "id", "hired_at", "terminated_at" FROM "cats";
+
+ALTER TABLE "payroll_runs" ADD CONSTRAINT "payroll_runs_period_excl"
+EXCLUDE USING gist (daterange("period_start", "period_end", '[]') WITH &&);
@@ -0,0 +1,12 @@
+ALTER TABLE "mission_handovers" DROP COLUMN IF EXISTS "actor";
+
+DELETE FROM "mission_members" WHERE "left_at" IS NOT NULL;
+
+DROP INDEX IF EXISTS "mission_members_lead_key";
+DROP INDEX IF EXISTS "mission_members_current_key";
+
+ALTER TABLE "mission_members" DROP COLUMN IF EXISTS "left_at";
+ALTER TABLE "mission_members" DROP COLUMN IF EXISTS "id";
+ALTER TABLE "mission_members" ADD PRIMARY KEY ("mission_id", "cat_id");
+
+CREATE UNIQUE INDEX "mission_members_lead_key" ON "mission_members" ("mission_id") WHERE "role" = 'lead';
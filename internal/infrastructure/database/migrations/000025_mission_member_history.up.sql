@@ -0,0 +1,12 @@
+ALTER TABLE "mission_members" DROP CONSTRAINT "mission_members_pkey";
+ALTER TABLE "mission_members" ADD COLUMN "id" BIGSERIAL PRIMARY KEY;
+ALTER TABLE "mission_members" ADD COLUMN "left_at" TIMESTAMPTZ DEFAULT NULL;
+
+DROP INDEX IF EXISTS "mission_members_lead_key";
+CREATE UNIQUE INDEX "mission_members_current_key" ON "mission_members" ("mission_id", "cat_id") WHERE "left_at" IS NULL;
+CREATE UNIQUE INDEX "mission_members_lead_key" ON "mission_members" ("mission_id") WHERE "role" = 'lead' AND "left_at" IS NULL;
+
+ALTER TABLE "mission_handovers" ADD COLUMN "actor" VARCHAR NOT NULL DEFAULT 'system';
+
+INSERT INTO "mission_members" ("mission_id", "cat_id", "role", "joined_at", "left_at")
+SELECT "mission_id", "from_cat_id", 'lead', COALESCE("from_assigned_at", "created_at"), "created_at" FROM "mission_handovers";
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS "missions_set_members_active" ON "missions";
+DROP FUNCTION IF EXISTS "mission_state_set_members_active"();
+
+DROP TRIGGER IF EXISTS "mission_members_set_active" ON "mission_members";
+DROP FUNCTION IF EXISTS "mission_member_set_active"();
+
+DROP INDEX IF EXISTS "mission_members_active_cat_key";
+
+ALTER TABLE "mission_members" DROP COLUMN IF EXISTS "active";
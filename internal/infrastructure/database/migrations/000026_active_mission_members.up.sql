@@ -0,0 +1,55 @@
+ALTER TABLE "mission_members" ADD COLUMN "active" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Teams formed before this migration may overlap. Rather than picking which
+-- mission keeps a cat, the migration stops and names the conflicts so that
+-- they can be resolved by hand before it is run again.
+DO $$
+DECLARE
+    conflicts TEXT;
+BEGIN
+    SELECT string_agg(format('cat %s on missions %s', c."cat_id", c."missions"), '; ' ORDER BY c."cat_id")
+    INTO conflicts
+    FROM (
+        SELECT mm."cat_id", string_agg(mm."mission_id"::TEXT, ', ' ORDER BY mm."mission_id") AS "missions"
+        FROM "mission_members" mm
+        JOIN "missions" m ON m."id" = mm."mission_id"
+        WHERE m."state" IN ('assigned', 'in_progress') AND mm."left_at" IS NULL
+        GROUP BY mm."cat_id"
+        HAVING COUNT(*) > 1
+    ) c;
+
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'cats are on more than one active mission: %', conflicts;
+    END IF;
+END;
+$$;
+
+UPDATE "mission_members" mm SET "active" = TRUE
+FROM "missions" m
+WHERE m."id" = mm."mission_id" AND m."state" IN ('assigned', 'in_progress') AND mm."left_at" IS NULL;
+
+CREATE UNIQUE INDEX "mission_members_active_cat_key" ON "mission_members" ("cat_id") WHERE "active" AND "left_at" IS NULL;
+
+CREATE FUNCTION "mission_member_set_active"() RETURNS TRIGGER AS $$
+BEGIN
+    NEW."active" := EXISTS (SELECT 1 FROM "missions" WHERE "id" = NEW."mission_id" AND "state" IN ('assigned', 'in_progress'));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "mission_members_set_active" BEFORE INSERT ON "mission_members"
+FOR EACH ROW EXECUTE PROCEDURE "mission_member_set_active"();
+
+CREATE FUNCTION "mission_state_set_members_active"() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE "mission_members" SET "active" = NEW."state" IN ('assigned', 'in_progress') WHERE "mission_id" = NEW."id";
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "missions_set_members_active" AFTER UPDATE OF "state" ON "missions"
+FOR EACH ROW WHEN (OLD."state" IS DISTINCT FROM NEW."state") EXECUTE PROCEDURE "mission_state_set_members_active"();
+
+UPDATE "targets" t SET "completed_by" = m."cat_id"
+FROM "missions" m
+WHERE m."id" = t."mission_id" AND t."is_completed" AND t."completed_by" IS NULL AND m."cat_id" IS NOT NULL;
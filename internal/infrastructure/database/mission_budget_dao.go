@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+
+	"github.com/lib/pq"
+)
+
+const expenseColumns = "id, mission_id, amount, category, spent_on, receipt_ref, created_at"
+
+func (r *missionRepository) SetBudget(budget models.MissionBudget) (*models.MissionBudget, error) {
+	query := `
+		INSERT INTO mission_budgets (mission_id, amount, policy) VALUES ($1, $2, $3)
+		ON CONFLICT (mission_id) DO UPDATE SET amount = EXCLUDED.amount, policy = EXCLUDED.policy, updated_at = NOW();
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	if _, err := r.conn().ExecContext(ctx, query, budget.MissionID, budget.Amount, budget.Policy); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	budgets, err := r.listBudgets(ctx, budget.MissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return budgets[budget.MissionID], nil
+}
+
+// LockBudget returns the mission's budget and, inside a transaction, holds its
+// row until commit so that concurrent expenses are checked one after another.
+func (r *missionRepository) LockBudget(missionID uint) (*models.MissionBudget, error) {
+	query := "SELECT mission_id FROM mission_budgets WHERE mission_id = $1 FOR UPDATE;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	if _, err := r.conn().ExecContext(ctx, query, missionID); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	budgets, err := r.listBudgets(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return budgets[missionID], nil
+}
+
+func (r *missionRepository) AddExpense(expense models.Expense) (*models.Expense, error) {
+	query := "INSERT INTO mission_expenses (mission_id, amount, category, spent_on, receipt_ref) VALUES ($1, $2, $3, $4, $5) RETURNING " + expenseColumns + ";"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.conn().QueryRowContext(ctx, query, expense.MissionID, expense.Amount, expense.Category, expense.SpentOn, expense.ReceiptRef)
+
+	var res models.Expense
+
+	if err := scanExpense(row, &res); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *missionRepository) ListExpenses(missionID uint) ([]models.Expense, error) {
+	list := []models.Expense{}
+	query := "SELECT " + expenseColumns + " FROM mission_expenses WHERE mission_id = $1 ORDER BY spent_on, id;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, missionID)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var expense models.Expense
+		if err := scanExpense(rows, &expense); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, expense)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+// listBudgets returns the budgets of the given missions with their spending so
+// far. Missions without a budget are absent from the map.
+func (r *missionRepository) listBudgets(ctx context.Context, missionIDs ...uint) (map[uint]*models.MissionBudget, error) {
+	res := make(map[uint]*models.MissionBudget, len(missionIDs))
+
+	ids := make([]int64, 0, len(missionIDs))
+	for _, id := range missionIDs {
+		ids = append(ids, int64(id))
+	}
+
+	query := `
+		SELECT b.mission_id, b.amount, b.policy, COALESCE(SUM(e.amount), 0), b.updated_at
+		FROM mission_budgets b
+		LEFT JOIN mission_expenses e ON e.mission_id = b.mission_id
+		WHERE b.mission_id = ANY($1)
+		GROUP BY b.mission_id;
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var budget models.MissionBudget
+		if err := rows.Scan(
+			&budget.MissionID,
+			&budget.Amount,
+			&budget.Policy,
+			&budget.Spent,
+			&budget.UpdatedAt,
+		); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		res[budget.MissionID] = &budget
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return res, nil
+}
+
+func scanExpense(row rowScanner, expense *models.Expense) error {
+	return row.Scan(
+		&expense.ID,
+		&expense.MissionID,
+		&expense.Amount,
+		&expense.Category,
+		&expense.SpentOn,
+		&expense.ReceiptRef,
+		&expense.CreatedAt,
+	)
+}
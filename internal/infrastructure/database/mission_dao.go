@@ -4,22 +4,43 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"spyCatAgency/internal/domain/models"
 	"spyCatAgency/internal/infrastructure/apperrors"
 	"spyCatAgency/internal/infrastructure/logger"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	targetColumns = "id, mission_id, name, country, notes, is_completed, created_at, completed_at, due_at, completed_by"
+
+	activeMissionConstraint = "missions_active_cat_id_key"
+	activeMemberConstraint  = "mission_members_active_cat_key"
+	activeMissionStates     = "('assigned', 'in_progress')"
+	closedMissionStates     = "('completed', 'aborted', 'failed')"
+
+	transitionColumns = "id, mission_id, from_state, to_state, actor, reason, created_at"
+
+	missionCursorKey = "missions:created_at:desc"
 )
 
+var missionSortColumn = sortColumn{name: "m.created_at", sqlType: "TIMESTAMPTZ"}
+
 type (
 	missionRepository struct {
 		logger logger.Logger
-		*sql.DB
+		executor
 	}
 )
 
 func NewMissonRepository(customLogger logger.Logger, r *sql.DB) *missionRepository {
 	return &missionRepository{
-		logger: customLogger,
-		DB:     r,
+		logger:   customLogger,
+		executor: executor{db: r},
 	}
 }
 
@@ -28,65 +49,144 @@ func (r *missionRepository) Add(mission models.Mission) (*models.Mission, error)
 	var res models.Mission
 	res.TargetList = make([]models.Target, 0)
 
-	query := "INSERT INTO missions (name) VALUES($1) RETURNING id, name, is_completed, created_at;"
-
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	row := r.QueryRowContext(ctx, query, mission.Name)
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := "INSERT INTO missions (name, type, due_at, cloned_from_id) VALUES($1, $2, $3, $4) RETURNING id, name, state, is_completed, created_at, completed_at, closed_at, assigned_at, due_at, overdue_at, cloned_from_id, type;"
+
+		err := conn.QueryRowContext(ctx, query, mission.Name, mission.Type, mission.DueAt, mission.ClonedFromID).Scan(
+			&res.ID,
+			&res.Name,
+			&res.State,
+			&res.IsCompleted,
+			&res.CreatedAt,
+			&res.CompletedAt,
+			&res.ClosedAt,
+			&res.AssignedAt,
+			&res.DueAt,
+			&res.OverdueAt,
+			&res.ClonedFromID,
+			&res.Type,
+		)
 
-	err := row.Scan(
-		&res.ID,
-		&res.Name,
-		&res.IsCompleted,
-		&res.CreatedAt,
-	)
+		if err != nil {
+			return err
+		}
 
-	if err != nil {
-		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
-		return nil, apperrors.ErrDatabase
-	}
+		for _, v := range mission.TargetList {
+			query := "INSERT INTO targets (name, country, notes, mission_id, due_at) VALUES ($1, $2, $3, $4, $5) RETURNING " + targetColumns + ";"
 
-	for _, v := range mission.TargetList {
-		query := "INSERT INTO targets (name, country, notes, mission_id) VALUES ($1, $2, $3, $4) RETURNING id, mission_id, name, country, notes, is_completed, created_at ;"
+			row := conn.QueryRowContext(ctx, query, v.Name, v.Country, v.Notes, res.ID, v.DueAt)
 
-		row := r.QueryRowContext(ctx, query, v.Name, v.Country, v.Notes, res.ID)
+			var target models.Target
 
-		var target models.Target
+			err := row.Scan(
+				&target.ID,
+				&target.MissionID,
+				&target.Name,
+				&target.Country,
+				&target.Notes,
+				&target.IsCompleted,
+				&target.CreatedAt,
+				&target.CompletedAt,
+				&target.DueAt,
+				&target.CompletedBy,
+			)
 
-		err := row.Scan(
-			&target.ID,
-			&target.MissionID,
-			&target.Name,
-			&target.Country,
-			&target.Notes,
-			&target.IsCompleted,
-			&target.CreatedAt,
-		)
+			if err != nil {
+				return err
+			}
+			res.TargetList = append(res.TargetList, target)
+		}
 
-		if err != nil {
-			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
-			return nil, apperrors.ErrDatabase
+		for _, v := range mission.RequiredSkills {
+			query := "INSERT INTO mission_skill_requirements (mission_id, skill_id, min_level) VALUES ($1, $2, $3);"
+
+			if _, err := conn.ExecContext(ctx, query, res.ID, v.SkillID, v.MinLevel); err != nil {
+				return err
+			}
 		}
-		res.TargetList = append(res.TargetList, target)
+
+		return nil
+	})
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
 	}
 
-	//r.logger.Warnf("%v", *mission)
+	requirements, err := r.listRequirements(ctx, res.ID)
+	if err != nil {
+		return nil, err
+	}
+	res.RequiredSkills = requirements[res.ID]
+	res.Team = make([]models.MissionMember, 0)
 
 	return &res, nil
 }
 
+// AssignToCat makes the cat the mission's lead, promoting it if it was already
+// on the team in another role. A previous lead leaves the team.
 func (r *missionRepository) AssignToCat(missionId, catId uint) error {
-	query := "UPDATE missions SET cat_id = $1 WHERE id = $2;"
-
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	_, err := r.ExecContext(ctx, query, catId, missionId)
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := "UPDATE missions SET cat_id = $1, assigned_at = NOW() WHERE id = $2;"
+
+		if _, err := conn.ExecContext(ctx, query, catId, missionId); err != nil {
+			return err
+		}
+
+		query = "UPDATE mission_members SET left_at = NOW() WHERE mission_id = $1 AND role = 'lead' AND cat_id <> $2 AND left_at IS NULL;"
+
+		if _, err := conn.ExecContext(ctx, query, missionId, catId); err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO mission_members (mission_id, cat_id, role) VALUES ($1, $2, 'lead')
+			ON CONFLICT (mission_id, cat_id) WHERE left_at IS NULL DO UPDATE SET role = EXCLUDED.role;
+		`
+
+		_, err := conn.ExecContext(ctx, query, missionId, catId)
+		return err
+	})
 
 	if err != nil {
 
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+
+		if isActiveMissionConflict(err) {
+			return apperrors.ErrConflictf("This cat is already on an active mission")
+		}
+
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (r *missionRepository) UnassignCat(missionId uint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := "UPDATE missions SET cat_id = NULL, assigned_at = NULL WHERE id = $1;"
+
+		if _, err := conn.ExecContext(ctx, query, missionId); err != nil {
+			return err
+		}
+
+		query = "UPDATE mission_members SET left_at = NOW() WHERE mission_id = $1 AND role = 'lead' AND left_at IS NULL;"
+
+		_, err := conn.ExecContext(ctx, query, missionId)
+		return err
+	})
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
 		return apperrors.ErrDatabase
 	}
 
@@ -104,13 +204,24 @@ func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
 		    m.cat_id, 
 		    m.is_completed, 
 		    m.created_at, 
+		    m.completed_at, 
+		    m.state,
+		    m.closed_at,
+		    m.assigned_at,
+		    m.due_at,
+		    m.overdue_at,
+		    m.cloned_from_id,
+		    m.type,
 		    t.id, 
 		    t.mission_id, 
 		    t.name, 
 		    t.country, 
 		    t.notes, 
 		    t.is_completed, 
-		    t.created_at
+		    t.created_at,
+		    t.completed_at,
+		    t.due_at,
+		    t.completed_by
 		FROM missions m
 		JOIN targets t ON m.id = t.mission_id
 		WHERE m.id = $1;
@@ -118,7 +229,7 @@ func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
-	rows, err := r.QueryContext(ctx, query, id)
+	rows, err := r.conn().QueryContext(ctx, query, id)
 
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
@@ -137,6 +248,14 @@ func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
 			&mission.CatId,
 			&mission.IsCompleted,
 			&mission.CreatedAt,
+			&mission.CompletedAt,
+			&mission.State,
+			&mission.ClosedAt,
+			&mission.AssignedAt,
+			&mission.DueAt,
+			&mission.OverdueAt,
+			&mission.ClonedFromID,
+			&mission.Type,
 			&target.ID,
 			&target.MissionID,
 			&target.Name,
@@ -144,6 +263,9 @@ func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
 			&target.Notes,
 			&target.IsCompleted,
 			&target.CreatedAt,
+			&target.CompletedAt,
+			&target.DueAt,
+			&target.CompletedBy,
 		)
 		if err != nil {
 			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
@@ -157,10 +279,44 @@ func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
 		return nil, nil
 	}
 
+	requirements, err := r.listRequirements(ctx, mission.ID)
+	if err != nil {
+		return nil, err
+	}
+	mission.RequiredSkills = requirements[mission.ID]
+
+	members, err := r.listMembers(ctx, mission.ID)
+	if err != nil {
+		return nil, err
+	}
+	mission.Team = members[mission.ID]
+
+	budgets, err := r.listBudgets(ctx, mission.ID)
+	if err != nil {
+		return nil, err
+	}
+	mission.Budget = budgets[mission.ID]
+
 	return &mission, nil
 }
 
-func (r *missionRepository) GetByCatID(catID uint) (*models.Mission, error) {
+// GetForUpdate reads the mission and, inside a transaction, locks its row until
+// commit so that changes to its targets and team are applied one at a time.
+func (r *missionRepository) GetForUpdate(id uint) (*models.Mission, error) {
+	query := "SELECT id FROM missions WHERE id = $1 FOR UPDATE;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	if _, err := r.conn().ExecContext(ctx, query, id); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return r.GetByID(id)
+}
+
+func (r *missionRepository) GetActiveByCatID(catID uint) (*models.Mission, error) {
 	var mission models.Mission
 	mission.TargetList = make([]models.Target, 0)
 	notFound := true
@@ -172,22 +328,34 @@ func (r *missionRepository) GetByCatID(catID uint) (*models.Mission, error) {
 		    m.cat_id, 
 		    m.is_completed, 
 		    m.created_at, 
+		    m.completed_at, 
+		    m.state,
+		    m.closed_at,
+		    m.assigned_at,
+		    m.due_at,
+		    m.overdue_at,
+		    m.cloned_from_id,
+		    m.type,
 		    t.id, 
 		    t.mission_id, 
 		    t.name, 
 		    t.country, 
 		    t.notes, 
 		    t.is_completed, 
-		    t.created_at
+		    t.created_at,
+		    t.completed_at,
+		    t.due_at,
+		    t.completed_by
 		FROM missions m
 		JOIN targets t ON m.id = t.mission_id
-		WHERE m.cat_id = $1;
+		WHERE m.state IN ` + activeMissionStates + `
+		  AND (m.cat_id = $1 OR EXISTS (SELECT 1 FROM mission_members mm WHERE mm.mission_id = m.id AND mm.cat_id = $1 AND mm.left_at IS NULL));
 	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	rows, err := r.QueryContext(ctx, query, catID)
+	rows, err := r.conn().QueryContext(ctx, query, catID)
 
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
@@ -205,6 +373,14 @@ func (r *missionRepository) GetByCatID(catID uint) (*models.Mission, error) {
 			&mission.CatId,
 			&mission.IsCompleted,
 			&mission.CreatedAt,
+			&mission.CompletedAt,
+			&mission.State,
+			&mission.ClosedAt,
+			&mission.AssignedAt,
+			&mission.DueAt,
+			&mission.OverdueAt,
+			&mission.ClonedFromID,
+			&mission.Type,
 			&target.ID,
 			&target.MissionID,
 			&target.Name,
@@ -212,6 +388,9 @@ func (r *missionRepository) GetByCatID(catID uint) (*models.Mission, error) {
 			&target.Notes,
 			&target.IsCompleted,
 			&target.CreatedAt,
+			&target.CompletedAt,
+			&target.DueAt,
+			&target.CompletedBy,
 		)
 		if err != nil {
 			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
@@ -226,16 +405,181 @@ func (r *missionRepository) GetByCatID(catID uint) (*models.Mission, error) {
 		return nil, nil
 	}
 
+	requirements, err := r.listRequirements(ctx, mission.ID)
+	if err != nil {
+		return nil, err
+	}
+	mission.RequiredSkills = requirements[mission.ID]
+
+	members, err := r.listMembers(ctx, mission.ID)
+	if err != nil {
+		return nil, err
+	}
+	mission.Team = members[mission.ID]
+
+	budgets, err := r.listBudgets(ctx, mission.ID)
+	if err != nil {
+		return nil, err
+	}
+	mission.Budget = budgets[mission.ID]
+
 	return &mission, nil
 }
 
+// ListByCatID returns the cat's missions, newest first, including those the cat
+// has since left or handed over. A date range keeps the missions that were open
+// at some point inside it.
+func (r *missionRepository) ListByCatID(query models.CatMissionsQuery) ([]models.Mission, error) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	addCondition(`(m.cat_id = $%[1]d
+		OR EXISTS (SELECT 1 FROM mission_members mm WHERE mm.mission_id = m.id AND mm.cat_id = $%[1]d)
+		OR EXISTS (SELECT 1 FROM mission_handovers h WHERE h.mission_id = m.id AND h.from_cat_id = $%[1]d))`, query.CatID)
+
+	switch query.Status {
+	case "":
+	case models.MissionStatusActive:
+		conditions = append(conditions, "m.state IN "+activeMissionStates)
+		addCondition("EXISTS (SELECT 1 FROM mission_members mm WHERE mm.mission_id = m.id AND mm.cat_id = $%d AND mm.left_at IS NULL)", query.CatID)
+	default:
+		addCondition("m.state = $%d", query.Status)
+	}
+
+	if query.From != nil {
+		addCondition("(m.closed_at IS NULL OR m.closed_at >= $%d)", *query.From)
+	}
+	if query.To != nil {
+		addCondition("m.created_at < $%d", query.To.AddDate(0, 0, 1))
+	}
+
+	sqlQuery := `
+		SELECT
+		    m.id,
+		    m.name,
+		    m.cat_id,
+		    m.is_completed,
+		    m.created_at,
+		    m.completed_at,
+		    m.state,
+		    m.closed_at,
+		    m.assigned_at,
+		    m.due_at,
+		    m.overdue_at,
+		    m.cloned_from_id,
+		    m.type,
+		    t.id,
+		    t.mission_id,
+		    t.name,
+		    t.country,
+		    t.notes,
+		    t.is_completed,
+		    t.created_at,
+		    t.completed_at,
+		    t.due_at,
+		    t.completed_by
+		FROM missions m
+		JOIN targets t ON m.id = t.mission_id` + whereClause(conditions) + `
+		ORDER BY m.created_at DESC, m.id DESC, t.id;
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+	defer rows.Close()
+
+	missions := make([]models.Mission, 0)
+	missionIDs := make([]uint, 0)
+
+	for rows.Next() {
+		var target models.Target
+		var mission models.Mission
+
+		err := rows.Scan(
+			&mission.ID,
+			&mission.Name,
+			&mission.CatId,
+			&mission.IsCompleted,
+			&mission.CreatedAt,
+			&mission.CompletedAt,
+			&mission.State,
+			&mission.ClosedAt,
+			&mission.AssignedAt,
+			&mission.DueAt,
+			&mission.OverdueAt,
+			&mission.ClonedFromID,
+			&mission.Type,
+			&target.ID,
+			&target.MissionID,
+			&target.Name,
+			&target.Country,
+			&target.Notes,
+			&target.IsCompleted,
+			&target.CreatedAt,
+			&target.CompletedAt,
+			&target.DueAt,
+			&target.CompletedBy,
+		)
+		if err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		if len(missions) == 0 || missions[len(missions)-1].ID != mission.ID {
+			mission.TargetList = make([]models.Target, 0)
+			missions = append(missions, mission)
+			missionIDs = append(missionIDs, mission.ID)
+		}
+
+		last := &missions[len(missions)-1]
+		last.TargetList = append(last.TargetList, target)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	requirements, err := r.listRequirements(ctx, missionIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.listMembers(ctx, missionIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := r.listBudgets(ctx, missionIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range missions {
+		missions[i].RequiredSkills = requirements[missions[i].ID]
+		missions[i].Team = members[missions[i].ID]
+		missions[i].Budget = budgets[missions[i].ID]
+	}
+
+	return missions, nil
+}
+
 func (r *missionRepository) Delete(id uint) error {
 	query := "DELETE FROM missions WHERE id = $1;"
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	_, err := r.ExecContext(ctx, query, id)
+	_, err := r.conn().ExecContext(ctx, query, id)
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
 		return apperrors.ErrDatabase
@@ -244,38 +588,130 @@ func (r *missionRepository) Delete(id uint) error {
 	return nil
 }
 
-func (r *missionRepository) List() ([]models.Mission, error) {
-	query := `
-		SELECT 
-		    m.id AS mission_id, 
-		    m.name AS mission_name, 
-		    m.cat_id, 
-		    m.is_completed AS mission_completed, 
-		    m.created_at AS mission_created_at, 
-		    t.id AS target_id,
-		    t.mission_id, 
-		    t.name AS target_name, 
-		    t.country, 
-		    t.notes, 
-		    t.is_completed AS target_completed, 
-		    t.created_at AS target_created_at
-		FROM missions m
-		JOIN targets t ON m.id = t.mission_id
-		ORDER BY m.id;
-	`
+// List returns one page of missions, newest first. Only the missions of the
+// page are joined with their targets, so the query stays bounded by the limit.
+func (r *missionRepository) List(listQuery models.MissionListQuery) (*models.MissionPage, error) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	switch listQuery.Status {
+	case "":
+	case models.MissionStatusActive:
+		conditions = append(conditions, "m.state IN "+activeMissionStates)
+	default:
+		addCondition("m.state = $%d", listQuery.Status)
+	}
+
+	if listQuery.Assigned != nil {
+		if *listQuery.Assigned {
+			conditions = append(conditions, "m.cat_id IS NOT NULL")
+		} else {
+			conditions = append(conditions, "m.cat_id IS NULL")
+		}
+	}
+	if listQuery.CatID != nil {
+		addCondition("EXISTS (SELECT 1 FROM mission_members mm WHERE mm.mission_id = m.id AND mm.cat_id = $%d AND mm.left_at IS NULL)", *listQuery.CatID)
+	}
+	if listQuery.Country != "" {
+		addCondition("EXISTS (SELECT 1 FROM targets ct WHERE ct.mission_id = m.id AND LOWER(ct.country) = LOWER($%d))", listQuery.Country)
+	}
+	if listQuery.CreatedFrom != nil {
+		addCondition("m.created_at >= $%d", *listQuery.CreatedFrom)
+	}
+	if listQuery.CreatedTo != nil {
+		addCondition("m.created_at < $%d", listQuery.CreatedTo.AddDate(0, 0, 1))
+	}
+	if listQuery.Overdue != nil {
+		if *listQuery.Overdue {
+			conditions = append(conditions, "m.due_at < NOW() AND m.state NOT IN "+closedMissionStates)
+		} else {
+			conditions = append(conditions, "(m.due_at IS NULL OR m.due_at >= NOW() OR m.state IN "+closedMissionStates+")")
+		}
+	}
+	if listQuery.DueBefore != nil {
+		addCondition("m.due_at < $%d", *listQuery.DueBefore)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	rows, err := r.QueryContext(ctx, query)
+	var page models.MissionPage
+	page.List = make([]models.Mission, 0)
+
+	if listQuery.WithTotalCount {
+		countQuery := "SELECT COUNT(*) FROM missions m" + whereClause(conditions) + ";"
+
+		var total uint
+		if err := r.conn().QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		page.TotalCount = &total
+	}
+
+	if listQuery.Cursor != "" {
+		after, err := decodeCursor(listQuery.Cursor, missionCursorKey, missionSortColumn)
+		if err != nil {
+			r.logger.Warnf(apperrors.ErrBadRequestMsg(err.Error()))
+			return nil, apperrors.ErrBadRequestf("Invalid cursor")
+		}
+
+		args = append(args, after.Value, after.ID)
+		conditions = append(conditions, fmt.Sprintf(
+			"(m.created_at, m.id) < ($%d::TIMESTAMPTZ, $%d::BIGINT)",
+			len(args)-1, len(args),
+		))
+	}
+
+	args = append(args, listQuery.Limit+1)
+	query := `
+		WITH page AS (
+		    SELECT m.*
+		    FROM missions m` + whereClause(conditions) + `
+		    ORDER BY m.created_at DESC, m.id DESC
+		    LIMIT $` + strconv.Itoa(len(args)) + `
+		)
+		SELECT
+		    m.id,
+		    m.name,
+		    m.cat_id,
+		    m.is_completed,
+		    m.created_at,
+		    m.completed_at,
+		    m.state,
+		    m.closed_at,
+		    m.assigned_at,
+		    m.due_at,
+		    m.overdue_at,
+		    m.cloned_from_id,
+		    m.type,
+		    t.id,
+		    t.mission_id,
+		    t.name,
+		    t.country,
+		    t.notes,
+		    t.is_completed,
+		    t.created_at,
+		    t.completed_at,
+		    t.due_at,
+		    t.completed_by
+		FROM page m
+		JOIN targets t ON m.id = t.mission_id
+		ORDER BY m.created_at DESC, m.id DESC, t.id;
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
 		return nil, apperrors.ErrDatabase
 	}
 	defer rows.Close()
 
-	missionMap := make(map[uint]*models.Mission)
-	missions := make([]models.Mission, 0)
+	missionIDs := make([]uint, 0)
 
 	for rows.Next() {
 		var target models.Target
@@ -287,6 +723,14 @@ func (r *missionRepository) List() ([]models.Mission, error) {
 			&mission.CatId,
 			&mission.IsCompleted,
 			&mission.CreatedAt,
+			&mission.CompletedAt,
+			&mission.State,
+			&mission.ClosedAt,
+			&mission.AssignedAt,
+			&mission.DueAt,
+			&mission.OverdueAt,
+			&mission.ClonedFromID,
+			&mission.Type,
 			&target.ID,
 			&target.MissionID,
 			&target.Name,
@@ -294,57 +738,221 @@ func (r *missionRepository) List() ([]models.Mission, error) {
 			&target.Notes,
 			&target.IsCompleted,
 			&target.CreatedAt,
+			&target.CompletedAt,
+			&target.DueAt,
+			&target.CompletedBy,
 		)
 		if err != nil {
 			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
 			return nil, apperrors.ErrDatabase
 		}
 
-		if _, exists := missionMap[mission.ID]; !exists {
-			missionMap[mission.ID] = &models.Mission{
-				ID:          mission.ID,
-				CatId:       mission.CatId,
-				Name:        mission.Name,
-				IsCompleted: mission.IsCompleted,
-				CreatedAt:   mission.CreatedAt,
-				TargetList:  []models.Target{},
-			}
+		if len(page.List) == 0 || page.List[len(page.List)-1].ID != mission.ID {
+			mission.TargetList = make([]models.Target, 0)
+			page.List = append(page.List, mission)
+			missionIDs = append(missionIDs, mission.ID)
 		}
 
-		missionMap[mission.ID].TargetList = append(missionMap[mission.ID].TargetList, target)
+		last := &page.List[len(page.List)-1]
+		last.TargetList = append(last.TargetList, target)
 	}
 
-	for _, mission := range missionMap {
-		missions = append(missions, *mission)
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
 	}
 
-	return missions, nil
+	if uint(len(page.List)) > listQuery.Limit {
+		page.List = page.List[:listQuery.Limit]
+		missionIDs = missionIDs[:listQuery.Limit]
+		last := page.List[len(page.List)-1]
+		page.NextCursor = encodeCursor(missionCursorKey, last.CreatedAt.Format(time.RFC3339Nano), last.ID)
+	}
+
+	requirements, err := r.listRequirements(ctx, missionIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.listMembers(ctx, missionIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := r.listBudgets(ctx, missionIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range page.List {
+		page.List[i].RequiredSkills = requirements[page.List[i].ID]
+		page.List[i].Team = members[page.List[i].ID]
+		page.List[i].Budget = budgets[page.List[i].ID]
+	}
+
+	return &page, nil
 }
 
-func (r *missionRepository) Update(id uint, completed bool) error {
-	query := "UPDATE missions SET is_completed = $1 WHERE id = $2;"
+func (r *missionRepository) listRequirements(ctx context.Context, missionIDs ...uint) (map[uint][]models.SkillRequirement, error) {
+	res := make(map[uint][]models.SkillRequirement, len(missionIDs))
+	for _, id := range missionIDs {
+		res[id] = []models.SkillRequirement{}
+	}
+
+	ids := make([]int64, 0, len(missionIDs))
+	for _, id := range missionIDs {
+		ids = append(ids, int64(id))
+	}
+
+	query := `
+		SELECT r.mission_id, r.skill_id, s.name, r.min_level
+		FROM mission_skill_requirements r
+		JOIN skills s ON s.id = r.skill_id
+		WHERE r.mission_id = ANY($1)
+		ORDER BY s.name;
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var missionID uint
+		var requirement models.SkillRequirement
+
+		if err := rows.Scan(
+			&missionID,
+			&requirement.SkillID,
+			&requirement.SkillName,
+			&requirement.MinLevel,
+		); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		res[missionID] = append(res[missionID], requirement)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
 
+	return res, nil
+}
+
+// SetState moves the mission from transition.FromState to transition.ToState and
+// records the transition. It fails with a conflict if the mission has left
+// FromState in the meantime.
+func (r *missionRepository) SetState(transition models.MissionTransition) (*models.MissionTransition, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	_, err := r.ExecContext(ctx, query, completed, id)
+	var res models.MissionTransition
+	var stale bool
+
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := `
+			UPDATE missions SET
+			    state = $1,
+			    completed_at = CASE WHEN $1 = 'completed' THEN NOW() ELSE completed_at END,
+			    closed_at = CASE WHEN $1 IN ` + closedMissionStates + ` THEN NOW() ELSE closed_at END
+			WHERE id = $2 AND state = $3;
+		`
+
+		result, err := conn.ExecContext(ctx, query, transition.ToState, transition.MissionID, transition.FromState)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			stale = true
+			return nil
+		}
+
+		query = "INSERT INTO mission_transitions (mission_id, from_state, to_state, actor, reason) VALUES ($1, $2, $3, $4, $5) RETURNING " + transitionColumns + ";"
+
+		return scanTransition(conn.QueryRowContext(ctx, query, transition.MissionID, transition.FromState, transition.ToState, transition.Actor, transition.Reason), &res)
+	})
 
 	if err != nil {
 		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
-		return apperrors.ErrDatabase
+
+		if isActiveMissionConflict(err) {
+			return nil, apperrors.ErrConflictf("This cat is already on an active mission")
+		}
+
+		return nil, apperrors.ErrDatabase
 	}
 
-	return nil
+	if stale {
+		return nil, apperrors.ErrConflictf("Mission state has changed, reload and try again")
+	}
+
+	return &res, nil
+}
+
+func (r *missionRepository) ListTransitions(missionID uint) ([]models.MissionTransition, error) {
+	list := []models.MissionTransition{}
+	query := "SELECT " + transitionColumns + " FROM mission_transitions WHERE mission_id = $1 ORDER BY created_at, id;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, missionID)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var transition models.MissionTransition
+		if err := scanTransition(rows, &transition); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, transition)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func scanTransition(row rowScanner, transition *models.MissionTransition) error {
+	return row.Scan(
+		&transition.ID,
+		&transition.MissionID,
+		&transition.FromState,
+		&transition.ToState,
+		&transition.Actor,
+		&transition.Reason,
+		&transition.CreatedAt,
+	)
 }
 
 func (r *missionRepository) GetTarget(id uint) (*models.Target, error) {
 	var target models.Target
-	query := "SELECT * FROM targets WHERE id = $1"
+	query := "SELECT " + targetColumns + " FROM targets WHERE id = $1;"
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	row := r.QueryRowContext(ctx, query, id)
+	row := r.conn().QueryRowContext(ctx, query, id)
 	err := row.Scan(
 		&target.ID,
 		&target.MissionID,
@@ -353,6 +961,9 @@ func (r *missionRepository) GetTarget(id uint) (*models.Target, error) {
 		&target.Notes,
 		&target.IsCompleted,
 		&target.CreatedAt,
+		&target.CompletedAt,
+		&target.DueAt,
+		&target.CompletedBy,
 	)
 
 	if err != nil {
@@ -372,17 +983,23 @@ func (r *missionRepository) DeleteTarget(id uint) error {
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	_, err := r.ExecContext(ctx, query, id)
-	return err
+	_, err := r.conn().ExecContext(ctx, query, id)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
 }
 
 func (r *missionRepository) AddTarget(missionId uint, target models.Target) (*models.Target, error) {
-	query := "INSERT INTO targets (name, country, notes, mission_id) VALUES ($1, $2, $3, $4) RETURNING id, mission_id, name, country, notes, is_completed, created_at ;"
+	query := "INSERT INTO targets (name, country, notes, mission_id, due_at) VALUES ($1, $2, $3, $4, $5) RETURNING " + targetColumns + ";"
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	row := r.QueryRowContext(ctx, query, target.Name, target.Country, target.Notes, missionId)
+	row := r.conn().QueryRowContext(ctx, query, target.Name, target.Country, target.Notes, missionId, target.DueAt)
 
 	var res models.Target
 
@@ -394,6 +1011,9 @@ func (r *missionRepository) AddTarget(missionId uint, target models.Target) (*mo
 		&res.Notes,
 		&res.IsCompleted,
 		&res.CreatedAt,
+		&res.CompletedAt,
+		&res.DueAt,
+		&res.CompletedBy,
 	)
 
 	if err != nil {
@@ -403,13 +1023,13 @@ func (r *missionRepository) AddTarget(missionId uint, target models.Target) (*mo
 	return &res, nil
 }
 
-func (r *missionRepository) CompleteTarget(id uint) (*models.Target, error) {
-	query := "UPDATE targets SET is_completed = TRUE WHERE id = $1 RETURNING id, mission_id, name, country, notes, is_completed, created_at;"
+func (r *missionRepository) CompleteTarget(id uint, completedBy *uint) (*models.Target, error) {
+	query := "UPDATE targets SET is_completed = TRUE, completed_at = NOW(), completed_by = $2 WHERE id = $1 RETURNING " + targetColumns + ";"
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	row := r.QueryRowContext(ctx, query, id)
+	row := r.conn().QueryRowContext(ctx, query, id, completedBy)
 	var res models.Target
 
 	err := row.Scan(
@@ -420,6 +1040,9 @@ func (r *missionRepository) CompleteTarget(id uint) (*models.Target, error) {
 		&res.Notes,
 		&res.IsCompleted,
 		&res.CreatedAt,
+		&res.CompletedAt,
+		&res.DueAt,
+		&res.CompletedBy,
 	)
 
 	if err != nil {
@@ -429,13 +1052,31 @@ func (r *missionRepository) CompleteTarget(id uint) (*models.Target, error) {
 	return &res, nil
 }
 
-func (r *missionRepository) UpdateTargetNotes(id uint, notes string) (*models.Target, error) {
-	query := "UPDATE targets SET notes = $1 WHERE id = $2 RETURNING id, mission_id, name, country, notes, is_completed, created_at;"
+func (r *missionRepository) UpdateTarget(id uint, update models.TargetUpdate) (*models.Target, error) {
+	assignments := make([]string, 0)
+	args := make([]interface{}, 0)
+	addAssignment := func(column string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Notes != nil {
+		addAssignment("notes", *update.Notes)
+	}
+	if update.DueAt != nil {
+		addAssignment("due_at", *update.DueAt)
+	}
+
+	args = append(args, id)
+	query := fmt.Sprintf(
+		"UPDATE targets SET %s WHERE id = $%d RETURNING %s;",
+		strings.Join(assignments, ", "), len(args), targetColumns,
+	)
 
 	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
 	defer cancel()
 
-	row := r.QueryRowContext(ctx, query, notes, id)
+	row := r.conn().QueryRowContext(ctx, query, args...)
 	var res models.Target
 
 	err := row.Scan(
@@ -446,6 +1087,9 @@ func (r *missionRepository) UpdateTargetNotes(id uint, notes string) (*models.Ta
 		&res.Notes,
 		&res.IsCompleted,
 		&res.CreatedAt,
+		&res.CompletedAt,
+		&res.DueAt,
+		&res.CompletedBy,
 	)
 
 	if err != nil {
@@ -454,3 +1098,74 @@ func (r *missionRepository) UpdateTargetNotes(id uint, notes string) (*models.Ta
 	}
 	return &res, nil
 }
+
+// SetDueAt moves the mission deadline and clears any overdue flag raised for
+// the previous one.
+func (r *missionRepository) SetDueAt(id uint, dueAt time.Time) error {
+	query := "UPDATE missions SET due_at = $1, overdue_at = NULL WHERE id = $2;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, dueAt, id)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+// FlagOverdue marks open missions whose deadline passed before now and returns
+// the ones flagged by this call.
+func (r *missionRepository) FlagOverdue(now time.Time) ([]models.Mission, error) {
+	list := []models.Mission{}
+	query := `
+		UPDATE missions SET overdue_at = $1
+		WHERE due_at < $1 AND overdue_at IS NULL AND state NOT IN ` + closedMissionStates + `
+		RETURNING id, name, cat_id, state, due_at, overdue_at;
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, now)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var mission models.Mission
+		if err := rows.Scan(
+			&mission.ID,
+			&mission.Name,
+			&mission.CatId,
+			&mission.State,
+			&mission.DueAt,
+			&mission.OverdueAt,
+		); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, mission)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+// isActiveMissionConflict reports whether err comes from one of the indexes that
+// keep a cat on at most one active mission, as lead or as team member.
+func isActiveMissionConflict(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && (pqErr.Constraint == activeMissionConstraint || pqErr.Constraint == activeMemberConstraint)
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+
+	"github.com/lib/pq"
+)
+
+const memberColumns = "mission_id, cat_id, role, joined_at"
+
+func (r *missionRepository) AddMember(member models.MissionMember) (*models.MissionMember, error) {
+	query := "INSERT INTO mission_members (mission_id, cat_id, role) VALUES ($1, $2, $3) RETURNING " + memberColumns + ";"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.conn().QueryRowContext(ctx, query, member.MissionID, member.CatID, member.Role)
+
+	var res models.MissionMember
+
+	if err := scanMember(row, &res); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+
+		if isActiveMissionConflict(err) {
+			return nil, apperrors.ErrConflictf("This cat is already on an active mission")
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, apperrors.ErrConflictf("This cat is already on the mission team")
+		}
+
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+// RemoveMember ends the cat's membership; the row stays as part of the mission's history.
+func (r *missionRepository) RemoveMember(missionID, catID uint) error {
+	query := "UPDATE mission_members SET left_at = NOW() WHERE mission_id = $1 AND cat_id = $2 AND left_at IS NULL;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, missionID, catID)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+// listMembers returns the current teams of the given missions, lead first.
+func (r *missionRepository) listMembers(ctx context.Context, missionIDs ...uint) (map[uint][]models.MissionMember, error) {
+	res := make(map[uint][]models.MissionMember, len(missionIDs))
+	for _, id := range missionIDs {
+		res[id] = []models.MissionMember{}
+	}
+
+	ids := make([]int64, 0, len(missionIDs))
+	for _, id := range missionIDs {
+		ids = append(ids, int64(id))
+	}
+
+	query := `
+		SELECT ` + memberColumns + `
+		FROM mission_members
+		WHERE mission_id = ANY($1) AND left_at IS NULL
+		ORDER BY role = 'lead' DESC, joined_at, cat_id;
+	`
+
+	rows, err := r.conn().QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member models.MissionMember
+		if err := scanMember(rows, &member); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		res[member.MissionID] = append(res[member.MissionID], member)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return res, nil
+}
+
+func scanMember(row rowScanner, member *models.MissionMember) error {
+	return row.Scan(
+		&member.MissionID,
+		&member.CatID,
+		&member.Role,
+		&member.JoinedAt,
+	)
+}
@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+
+	"github.com/lib/pq"
+)
+
+const templateColumns = "id, name, name_pattern, mission_type, notes, created_at, updated_at"
+
+type (
+	missionTemplateRepository struct {
+		logger logger.Logger
+		executor
+	}
+)
+
+func NewMissionTemplateRepository(customLogger logger.Logger, r *sql.DB) *missionTemplateRepository {
+	return &missionTemplateRepository{
+		logger:   customLogger,
+		executor: executor{db: r},
+	}
+}
+
+func (r *missionTemplateRepository) Add(template models.MissionTemplate) (*models.MissionTemplate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res models.MissionTemplate
+
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := "INSERT INTO mission_templates (name, name_pattern, mission_type, notes) VALUES ($1, $2, $3, $4) RETURNING " + templateColumns + ";"
+
+		row := conn.QueryRowContext(ctx, query, template.Name, template.NamePattern, template.MissionType, template.Notes)
+		if err := scanTemplate(row, &res); err != nil {
+			return err
+		}
+
+		targets, err := insertTemplateTargets(ctx, conn, res.ID, template.Targets)
+		if err != nil {
+			return err
+		}
+
+		res.Targets = targets
+		return nil
+	})
+
+	if err != nil {
+		return nil, r.templateError(err)
+	}
+
+	return &res, nil
+}
+
+func (r *missionTemplateRepository) Get(id uint) (*models.MissionTemplate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	list, err := r.list(ctx, "WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	return &list[0], nil
+}
+
+func (r *missionTemplateRepository) List() ([]models.MissionTemplate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	return r.list(ctx, "")
+}
+
+// Update replaces the template and all of its target stubs. It returns nil, nil
+// when there is no template with such id.
+func (r *missionTemplateRepository) Update(template models.MissionTemplate) (*models.MissionTemplate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res *models.MissionTemplate
+
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := "UPDATE mission_templates SET name = $1, name_pattern = $2, mission_type = $3, notes = $4, updated_at = NOW() WHERE id = $5 RETURNING " + templateColumns + ";"
+
+		var updated models.MissionTemplate
+
+		row := conn.QueryRowContext(ctx, query, template.Name, template.NamePattern, template.MissionType, template.Notes, template.ID)
+		if err := scanTemplate(row, &updated); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		if _, err := conn.ExecContext(ctx, "DELETE FROM mission_template_targets WHERE template_id = $1;", template.ID); err != nil {
+			return err
+		}
+
+		targets, err := insertTemplateTargets(ctx, conn, updated.ID, template.Targets)
+		if err != nil {
+			return err
+		}
+
+		updated.Targets = targets
+		res = &updated
+		return nil
+	})
+
+	if err != nil {
+		return nil, r.templateError(err)
+	}
+
+	return res, nil
+}
+
+func (r *missionTemplateRepository) Delete(id uint) error {
+	query := "DELETE FROM mission_templates WHERE id = $1;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, id)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (r *missionTemplateRepository) list(ctx context.Context, where string, args ...interface{}) ([]models.MissionTemplate, error) {
+	list := []models.MissionTemplate{}
+	query := "SELECT " + templateColumns + " FROM mission_templates " + where + " ORDER BY name;"
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	index := make(map[uint]int)
+
+	for rows.Next() {
+		var template models.MissionTemplate
+		if err := scanTemplate(rows, &template); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		template.Targets = make([]models.TemplateTarget, 0)
+
+		index[template.ID] = len(list)
+		ids = append(ids, int64(template.ID))
+		list = append(list, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	if len(list) == 0 {
+		return list, nil
+	}
+
+	query = "SELECT template_id, name, country, notes FROM mission_template_targets WHERE template_id = ANY($1) ORDER BY position;"
+
+	targetRows, err := r.conn().QueryContext(ctx, query, pq.Array(ids))
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer targetRows.Close()
+
+	for targetRows.Next() {
+		var templateID uint
+		var target models.TemplateTarget
+
+		if err := targetRows.Scan(&templateID, &target.Name, &target.Country, &target.Notes); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		template := &list[index[templateID]]
+		template.Targets = append(template.Targets, target)
+	}
+
+	if err := targetRows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func (r *missionTemplateRepository) templateError(err error) error {
+	r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		return apperrors.ErrBadRequestf("Mission template with such name already exists")
+	}
+
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+		return apperrors.ErrBadRequestf("There is no mission type with such code")
+	}
+
+	return apperrors.ErrDatabase
+}
+
+func insertTemplateTargets(ctx context.Context, conn dbtx, templateID uint, targets []models.TemplateTarget) ([]models.TemplateTarget, error) {
+	query := "INSERT INTO mission_template_targets (template_id, position, name, country, notes) VALUES ($1, $2, $3, $4, $5);"
+
+	res := make([]models.TemplateTarget, 0, len(targets))
+	for position, target := range targets {
+		if _, err := conn.ExecContext(ctx, query, templateID, position, target.Name, target.Country, target.Notes); err != nil {
+			return nil, err
+		}
+		res = append(res, target)
+	}
+
+	return res, nil
+}
+
+func scanTemplate(row rowScanner, template *models.MissionTemplate) error {
+	return row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.NamePattern,
+		&template.MissionType,
+		&template.Notes,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+}
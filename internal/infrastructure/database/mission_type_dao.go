@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const missionTypeColumns = "code, name, min_targets, max_targets, allow_delete_completed_targets, allow_delete_assigned"
+
+type (
+	missionTypeRepository struct {
+		logger logger.Logger
+		*sql.DB
+	}
+)
+
+func NewMissionTypeRepository(customLogger logger.Logger, r *sql.DB) *missionTypeRepository {
+	return &missionTypeRepository{
+		logger: customLogger,
+		DB:     r,
+	}
+}
+
+func (r *missionTypeRepository) Add(missionType models.MissionType) (*models.MissionType, error) {
+	query := "INSERT INTO mission_types (" + missionTypeColumns + ") VALUES ($1, $2, $3, $4, $5, $6) RETURNING " + missionTypeColumns + ";"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.QueryRowContext(ctx, query,
+		missionType.Code,
+		missionType.Name,
+		missionType.MinTargets,
+		missionType.MaxTargets,
+		missionType.AllowDeleteCompletedTargets,
+		missionType.AllowDeleteAssigned,
+	)
+
+	var res models.MissionType
+
+	if err := scanMissionType(row, &res); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, apperrors.ErrBadRequestf("Mission type with such code already exists")
+		}
+
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *missionTypeRepository) List() ([]models.MissionType, error) {
+	list := []models.MissionType{}
+	query := "SELECT " + missionTypeColumns + " FROM mission_types ORDER BY code;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.QueryContext(ctx, query)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var missionType models.MissionType
+		if err := scanMissionType(rows, &missionType); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, missionType)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func (r *missionTypeRepository) Get(code string) (*models.MissionType, error) {
+	query := "SELECT " + missionTypeColumns + " FROM mission_types WHERE code = $1;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res models.MissionType
+
+	if err := scanMissionType(r.QueryRowContext(ctx, query, code), &res); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *missionTypeRepository) Update(code string, update models.MissionTypeUpdate) (*models.MissionType, error) {
+	assignments := make([]string, 0)
+	args := make([]interface{}, 0)
+	addAssignment := func(column string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Name != nil {
+		addAssignment("name", *update.Name)
+	}
+	if update.MinTargets != nil {
+		addAssignment("min_targets", *update.MinTargets)
+	}
+	if update.MaxTargets != nil {
+		addAssignment("max_targets", *update.MaxTargets)
+	}
+	if update.AllowDeleteCompletedTargets != nil {
+		addAssignment("allow_delete_completed_targets", *update.AllowDeleteCompletedTargets)
+	}
+	if update.AllowDeleteAssigned != nil {
+		addAssignment("allow_delete_assigned", *update.AllowDeleteAssigned)
+	}
+
+	args = append(args, code)
+	query := fmt.Sprintf(
+		"UPDATE mission_types SET %s WHERE code = $%d RETURNING %s;",
+		strings.Join(assignments, ", "), len(args), missionTypeColumns,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res models.MissionType
+
+	if err := scanMissionType(r.QueryRowContext(ctx, query, args...), &res); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func scanMissionType(row rowScanner, missionType *models.MissionType) error {
+	return row.Scan(
+		&missionType.Code,
+		&missionType.Name,
+		&missionType.MinTargets,
+		&missionType.MaxTargets,
+		&missionType.AllowDeleteCompletedTargets,
+		&missionType.AllowDeleteAssigned,
+	)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// cursor remembers the last row of a page. Key names the listing and sort
+	// order it was issued for, so it cannot be replayed against another one.
+	cursor struct {
+		Key   string `json:"k"`
+		Value string `json:"v"`
+		ID    uint   `json:"id"`
+	}
+
+	sortColumn struct {
+		name    string
+		sqlType string
+	}
+)
+
+func encodeCursor(key, value string, id uint) string {
+	raw, _ := json.Marshal(cursor{Key: key, Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor rejects cursors issued for a different key and values that the
+// sort column could not be compared with.
+func decodeCursor(encoded, key string, column sortColumn) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var res cursor
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, err
+	}
+
+	if res.Key != key {
+		return nil, errors.New("cursor was issued for a different listing or sort order")
+	}
+
+	if err := column.validate(res.Value); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// validate checks that value casts to the column's SQL type.
+func (c sortColumn) validate(value string) error {
+	var err error
+
+	switch c.sqlType {
+	case "BIGINT", "SMALLINT":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "DECIMAL":
+		_, err = strconv.ParseFloat(value, 64)
+	case "TIMESTAMPTZ":
+		_, err = time.Parse(time.RFC3339Nano, value)
+	}
+
+	return err
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
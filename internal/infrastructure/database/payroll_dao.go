@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type (
+	payrollRepository struct {
+		logger logger.Logger
+		*sql.DB
+	}
+)
+
+func NewPayrollRepository(customLogger logger.Logger, r *sql.DB) *payrollRepository {
+	return &payrollRepository{
+		logger: customLogger,
+		DB:     r,
+	}
+}
+
+func (r *payrollRepository) Add(run models.PayrollRun) (*models.PayrollRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO payroll_runs (period_start, period_end, total) VALUES ($1, $2, $3) RETURNING id, period_start, period_end, total, created_at;"
+
+	var res models.PayrollRun
+	res.Lines = make([]models.PayrollLine, 0)
+
+	err = tx.QueryRowContext(ctx, query, run.PeriodStart, run.PeriodEnd, run.Total).Scan(
+		&res.ID,
+		&res.PeriodStart,
+		&res.PeriodEnd,
+		&res.Total,
+		&res.CreatedAt,
+	)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, apperrors.ErrBadRequestf("Payroll run for this period already exists")
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "exclusion_violation" {
+			return nil, apperrors.ErrBadRequestf("Payroll period overlaps an existing run")
+		}
+
+		return nil, apperrors.ErrDatabase
+	}
+
+	for _, v := range run.Lines {
+		query := "INSERT INTO payroll_lines (run_id, cat_id, cat_name, salary, days_employed, days_in_period, amount) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, run_id, cat_id, cat_name, salary, days_employed, days_in_period, amount;"
+
+		var line models.PayrollLine
+
+		err := scanPayrollLine(tx.QueryRowContext(ctx, query, res.ID, v.CatID, v.CatName, v.Salary, v.DaysEmployed, v.DaysInPeriod, v.Amount), &line)
+
+		if err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		res.Lines = append(res.Lines, line)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *payrollRepository) Get(id uint) (*models.PayrollRun, error) {
+	query := "SELECT id, period_start, period_end, total, created_at FROM payroll_runs WHERE id = $1;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res models.PayrollRun
+	res.Lines = make([]models.PayrollLine, 0)
+
+	err := r.QueryRowContext(ctx, query, id).Scan(
+		&res.ID,
+		&res.PeriodStart,
+		&res.PeriodEnd,
+		&res.Total,
+		&res.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	query = "SELECT id, run_id, cat_id, cat_name, salary, days_employed, days_in_period, amount FROM payroll_lines WHERE run_id = $1 ORDER BY cat_id;"
+
+	rows, err := r.QueryContext(ctx, query, id)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var line models.PayrollLine
+		if err := scanPayrollLine(rows, &line); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		res.Lines = append(res.Lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+// HasOverlappingRun reports whether a stored run shares a day with [start, end].
+func (r *payrollRepository) HasOverlappingRun(start, end time.Time) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM payroll_runs WHERE period_start <= $2::DATE AND period_end >= $1::DATE);"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var exists bool
+	if err := r.QueryRowContext(ctx, query, start, end).Scan(&exists); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return false, apperrors.ErrDatabase
+	}
+
+	return exists, nil
+}
+
+func scanPayrollLine(row rowScanner, line *models.PayrollLine) error {
+	return row.Scan(
+		&line.ID,
+		&line.RunID,
+		&line.CatID,
+		&line.CatName,
+		&line.Salary,
+		&line.DaysEmployed,
+		&line.DaysInPeriod,
+		&line.Amount,
+	)
+}
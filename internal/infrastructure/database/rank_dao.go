@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strings"
+)
+
+const rankColumns = "code, name, position, min_years_of_experience, min_completed_missions, min_salary, max_salary"
+
+type (
+	rankRepository struct {
+		logger logger.Logger
+		*sql.DB
+	}
+)
+
+func NewRankRepository(customLogger logger.Logger, r *sql.DB) *rankRepository {
+	return &rankRepository{
+		logger: customLogger,
+		DB:     r,
+	}
+}
+
+func (r *rankRepository) List() ([]models.Rank, error) {
+	list := []models.Rank{}
+	query := "SELECT " + rankColumns + " FROM ranks ORDER BY position;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.QueryContext(ctx, query)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var rank models.Rank
+		if err := scanRank(rows, &rank); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, rank)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func (r *rankRepository) Get(code string) (*models.Rank, error) {
+	query := "SELECT " + rankColumns + " FROM ranks WHERE code = $1;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res models.Rank
+
+	if err := scanRank(r.QueryRowContext(ctx, query, code), &res); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *rankRepository) Update(code string, update models.RankUpdate) (*models.Rank, error) {
+	assignments := make([]string, 0)
+	args := make([]interface{}, 0)
+	addAssignment := func(column string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Name != nil {
+		addAssignment("name", *update.Name)
+	}
+	if update.MinYearsOfExperience != nil {
+		addAssignment("min_years_of_experience", *update.MinYearsOfExperience)
+	}
+	if update.MinCompletedMissions != nil {
+		addAssignment("min_completed_missions", *update.MinCompletedMissions)
+	}
+	if update.MinSalary != nil {
+		addAssignment("min_salary", *update.MinSalary)
+	}
+	if update.MaxSalary != nil {
+		addAssignment("max_salary", *update.MaxSalary)
+	}
+
+	args = append(args, code)
+	query := fmt.Sprintf(
+		"UPDATE ranks SET %s WHERE code = $%d RETURNING %s;",
+		strings.Join(assignments, ", "), len(args), rankColumns,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var res models.Rank
+
+	if err := scanRank(r.QueryRowContext(ctx, query, args...), &res); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+// CountCatsOutsideBand counts employed cats of the rank whose salary falls outside [minSalary, maxSalary].
+func (r *rankRepository) CountCatsOutsideBand(code string, minSalary, maxSalary float64) (uint, error) {
+	query := "SELECT COUNT(*) FROM cats WHERE rank = $1 AND terminated_at IS NULL AND salary NOT BETWEEN $2 AND $3;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var count uint
+
+	if err := r.QueryRowContext(ctx, query, code, minSalary, maxSalary).Scan(&count); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return 0, apperrors.ErrDatabase
+	}
+
+	return count, nil
+}
+
+func scanRank(row rowScanner, rank *models.Rank) error {
+	return row.Scan(
+		&rank.Code,
+		&rank.Name,
+		&rank.Position,
+		&rank.MinYearsOfExperience,
+		&rank.MinCompletedMissions,
+		&rank.MinSalary,
+		&rank.MaxSalary,
+	)
+}
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"time"
+)
+
+const (
+	salaryChangeColumns = "h.id, h.cat_id, o.old_salary, h.new_salary, h.reason, h.effective_at, h.created_at, o.outcome, o.note, o.created_at"
+	salaryChangeFrom    = " FROM salary_history h LEFT JOIN salary_change_outcomes o ON o.change_id = h.id"
+)
+
+// AddSalaryChange appends a change to the ledger. A change with AppliedAt set
+// is recorded together with its applied outcome.
+func (r *catRepository) AddSalaryChange(change models.SalaryChange) (*models.SalaryChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	var id uint
+
+	err := r.withinTx(ctx, func(conn dbtx) error {
+		query := "INSERT INTO salary_history (cat_id, new_salary, reason, effective_at) VALUES ($1, $2, $3, $4) RETURNING id;"
+
+		if err := conn.QueryRowContext(ctx, query, change.CatID, change.NewSalary, change.Reason, change.EffectiveAt).Scan(&id); err != nil {
+			return err
+		}
+
+		if change.AppliedAt == nil {
+			return nil
+		}
+
+		query = "INSERT INTO salary_change_outcomes (change_id, outcome, old_salary, created_at) VALUES ($1, $2, $3, $4);"
+
+		_, err := conn.ExecContext(ctx, query, id, models.SalaryChangeApplied, change.OldSalary, *change.AppliedAt)
+		return err
+	})
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return r.getSalaryChange(ctx, "SELECT "+salaryChangeColumns+salaryChangeFrom+" WHERE h.id = $1;", id)
+}
+
+func (r *catRepository) ListSalaryHistory(catID uint) ([]models.SalaryChange, error) {
+	query := "SELECT " + salaryChangeColumns + salaryChangeFrom + " WHERE h.cat_id = $1 ORDER BY h.effective_at, h.id;"
+
+	return r.querySalaryChanges(query, catID)
+}
+
+// ListAppliedSalaryChanges returns applied changes that took effect on or before
+// until, ordered by effective date.
+func (r *catRepository) ListAppliedSalaryChanges(until time.Time) ([]models.SalaryChange, error) {
+	query := "SELECT " + salaryChangeColumns + salaryChangeFrom + " WHERE o.outcome = 'applied' AND h.effective_at::DATE <= $1::DATE ORDER BY h.effective_at, h.id;"
+
+	return r.querySalaryChanges(query, until)
+}
+
+// ListDueSalaryChanges returns pending changes whose effective date has passed.
+func (r *catRepository) ListDueSalaryChanges(now time.Time) ([]models.SalaryChange, error) {
+	query := "SELECT " + salaryChangeColumns + salaryChangeFrom + " WHERE o.change_id IS NULL AND h.effective_at <= $1 ORDER BY h.effective_at, h.id;"
+
+	return r.querySalaryChanges(query, now)
+}
+
+// LockSalaryChange locks a pending change until the surrounding transaction
+// ends. It returns nil when the change is already resolved or another
+// transaction holds it.
+func (r *catRepository) LockSalaryChange(id uint) (*models.SalaryChange, error) {
+	query := `
+		SELECT h.id, h.cat_id, NULL::DECIMAL, h.new_salary, h.reason, h.effective_at, h.created_at, NULL, NULL, NULL::TIMESTAMPTZ
+		FROM salary_history h
+		WHERE h.id = $1 AND NOT EXISTS (SELECT 1 FROM salary_change_outcomes o WHERE o.change_id = h.id)
+		FOR UPDATE SKIP LOCKED;
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	return r.getSalaryChange(ctx, query, id)
+}
+
+func (r *catRepository) ResolveSalaryChange(outcome models.SalaryChangeOutcome) error {
+	query := "INSERT INTO salary_change_outcomes (change_id, outcome, old_salary, note) VALUES ($1, $2, $3, $4);"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, outcome.ChangeID, outcome.Outcome, outcome.OldSalary, outcome.Note)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+// CancelPendingSalaryChanges resolves every pending change of the cat as cancelled.
+func (r *catRepository) CancelPendingSalaryChanges(catID uint, note string) error {
+	query := `
+		INSERT INTO salary_change_outcomes (change_id, outcome, note)
+		SELECT h.id, $2, $3 FROM salary_history h
+		WHERE h.cat_id = $1 AND NOT EXISTS (SELECT 1 FROM salary_change_outcomes o WHERE o.change_id = h.id);
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, catID, models.SalaryChangeCancelled, note)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (r *catRepository) getSalaryChange(ctx context.Context, query string, args ...interface{}) (*models.SalaryChange, error) {
+	var res models.SalaryChange
+
+	if err := scanSalaryChange(r.conn().QueryRowContext(ctx, query, args...), &res); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *catRepository) querySalaryChanges(query string, args ...interface{}) ([]models.SalaryChange, error) {
+	list := []models.SalaryChange{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var change models.SalaryChange
+		if err := scanSalaryChange(rows, &change); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func scanSalaryChange(row rowScanner, change *models.SalaryChange) error {
+	var (
+		outcome    sql.NullString
+		note       sql.NullString
+		resolvedAt *time.Time
+	)
+
+	if err := row.Scan(
+		&change.ID,
+		&change.CatID,
+		&change.OldSalary,
+		&change.NewSalary,
+		&change.Reason,
+		&change.EffectiveAt,
+		&change.CreatedAt,
+		&outcome,
+		&note,
+		&resolvedAt,
+	); err != nil {
+		return err
+	}
+
+	switch outcome.String {
+	case models.SalaryChangeApplied:
+		change.AppliedAt = resolvedAt
+	case models.SalaryChangeCancelled:
+		change.CancelledAt = resolvedAt
+		change.CancelReason = note.String
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+
+	"github.com/lib/pq"
+)
+
+// Every branch ranks its rows by full-text relevance and falls back to trigram
+// similarity on the name, so misspelled names still produce a hit.
+const searchQuery = `
+WITH q AS (
+	SELECT websearch_to_tsquery('english', $1) AS tsq, $1::text AS raw
+)
+SELECT type, id, mission_id, title, snippet, rank FROM (
+	SELECT 'cat' AS type, c.id, NULL::bigint AS mission_id, c.name AS title,
+		ts_headline('english', c.name || ' (' || c.breed || ')', q.tsq, $2) AS snippet,
+		GREATEST(ts_rank_cd(c.search_vector, q.tsq), similarity(c.name, q.raw)) AS rank
+	FROM cats c, q
+	WHERE c.search_vector @@ q.tsq OR c.name % q.raw
+
+	UNION ALL
+
+	SELECT 'mission', m.id, NULL::bigint, m.name,
+		ts_headline('english', m.name, q.tsq, $2),
+		GREATEST(ts_rank_cd(m.search_vector, q.tsq), similarity(m.name, q.raw))
+	FROM missions m, q
+	WHERE m.search_vector @@ q.tsq OR m.name % q.raw
+
+	UNION ALL
+
+	SELECT 'target', t.id, t.mission_id, t.name,
+		ts_headline('english', t.name || ' — ' || t.country || ': ' || t.notes, q.tsq, $2),
+		GREATEST(ts_rank_cd(t.search_vector, q.tsq), similarity(t.name, q.raw))
+	FROM targets t, q
+	WHERE t.search_vector @@ q.tsq OR t.name % q.raw
+) hits
+WHERE type = ANY($3)
+ORDER BY rank DESC, type, id
+LIMIT $4;`
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \""
+
+type (
+	searchRepository struct {
+		logger logger.Logger
+		*sql.DB
+	}
+)
+
+func NewSearchRepository(customLogger logger.Logger, r *sql.DB) *searchRepository {
+	return &searchRepository{
+		logger: customLogger,
+		DB:     r,
+	}
+}
+
+func (r *searchRepository) Search(query models.SearchQuery) ([]models.SearchHit, error) {
+	list := []models.SearchHit{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.QueryContext(ctx, searchQuery, query.Text, headlineOptions, pq.Array(query.Types), query.Limit)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := rows.Scan(&hit.Type, &hit.ID, &hit.MissionID, &hit.Title, &hit.Snippet, &hit.Rank); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+
+		list = append(list, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+
+	"github.com/lib/pq"
+)
+
+type (
+	skillRepository struct {
+		logger logger.Logger
+		executor
+	}
+)
+
+func NewSkillRepository(customLogger logger.Logger, r *sql.DB) *skillRepository {
+	return &skillRepository{
+		logger:   customLogger,
+		executor: executor{db: r},
+	}
+}
+
+func (r *skillRepository) Add(skill models.Skill) (*models.Skill, error) {
+	query := "INSERT INTO skills (name, description) VALUES ($1, $2) RETURNING id, name, description, created_at;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.conn().QueryRowContext(ctx, query, skill.Name, skill.Description)
+
+	var res models.Skill
+
+	err := row.Scan(
+		&res.ID,
+		&res.Name,
+		&res.Description,
+		&res.CreatedAt,
+	)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, apperrors.ErrBadRequestf("Skill with such name already exists")
+		}
+
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *skillRepository) Get(id uint) (*models.Skill, error) {
+	query := "SELECT id, name, description, created_at FROM skills WHERE id = $1;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	row := r.conn().QueryRowContext(ctx, query, id)
+
+	var res models.Skill
+
+	err := row.Scan(
+		&res.ID,
+		&res.Name,
+		&res.Description,
+		&res.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return &res, nil
+}
+
+func (r *skillRepository) List() ([]models.Skill, error) {
+	list := []models.Skill{}
+	query := "SELECT id, name, description, created_at FROM skills ORDER BY name;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var skill models.Skill
+		if err := rows.Scan(
+			&skill.ID,
+			&skill.Name,
+			&skill.Description,
+			&skill.CreatedAt,
+		); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, skill)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
+
+func (r *skillRepository) SetCatSkill(catID, skillID, level uint) error {
+	query := `
+		INSERT INTO cat_skills (cat_id, skill_id, level) VALUES ($1, $2, $3)
+		ON CONFLICT (cat_id, skill_id) DO UPDATE SET level = EXCLUDED.level, updated_at = NOW();
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, catID, skillID, level)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (r *skillRepository) DeleteCatSkill(catID, skillID uint) error {
+	query := "DELETE FROM cat_skills WHERE cat_id = $1 AND skill_id = $2;"
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	_, err := r.conn().ExecContext(ctx, query, catID, skillID)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (r *skillRepository) ListCatSkills(catID uint) ([]models.CatSkill, error) {
+	list := []models.CatSkill{}
+	query := `
+		SELECT cs.skill_id, s.name, cs.level
+		FROM cat_skills cs
+		JOIN skills s ON s.id = cs.skill_id
+		WHERE cs.cat_id = $1
+		ORDER BY s.name;
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DBTimeout)
+	defer cancel()
+
+	rows, err := r.conn().QueryContext(ctx, query, catID)
+
+	if err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var catSkill models.CatSkill
+		if err := rows.Scan(
+			&catSkill.SkillID,
+			&catSkill.SkillName,
+			&catSkill.Level,
+		); err != nil {
+			r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+			return nil, apperrors.ErrDatabase
+		}
+		list = append(list, catSkill)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return nil, apperrors.ErrDatabase
+	}
+
+	return list, nil
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"spyCatAgency/internal/domain/usecases"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+)
+
+// TxTimeout bounds a whole unit of work, which usually spans several queries.
+const TxTimeout = DBTimeout * 5
+
+type (
+	dbtx interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+		QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+		PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	}
+
+	// executor lets a repository run either on the connection pool or inside a
+	// transaction opened by the transaction manager.
+	executor struct {
+		db *sql.DB
+		tx *sql.Tx
+	}
+
+	transactionManager struct {
+		logger logger.Logger
+		*sql.DB
+	}
+
+	unitOfWork struct {
+		cats     *catRepository
+		missions *missionRepository
+		skills   *skillRepository
+	}
+)
+
+func (e executor) conn() dbtx {
+	if e.tx != nil {
+		return e.tx
+	}
+	return e.db
+}
+
+// withinTx runs fn on the surrounding transaction if there is one, otherwise
+// on a transaction of its own.
+func (e executor) withinTx(ctx context.Context, fn func(conn dbtx) error) error {
+	if e.tx != nil {
+		return fn(e.tx)
+	}
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func NewTransactionManager(customLogger logger.Logger, r *sql.DB) *transactionManager {
+	return &transactionManager{
+		logger: customLogger,
+		DB:     r,
+	}
+}
+
+func (m *transactionManager) WithinTransaction(fn func(uow usecases.UnitOfWork) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), TxTimeout)
+	defer cancel()
+
+	tx, err := m.BeginTx(ctx, nil)
+	if err != nil {
+		m.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+	defer tx.Rollback()
+
+	uow := &unitOfWork{
+		cats:     &catRepository{logger: m.logger, executor: executor{tx: tx}},
+		missions: &missionRepository{logger: m.logger, executor: executor{tx: tx}},
+		skills:   &skillRepository{logger: m.logger, executor: executor{tx: tx}},
+	}
+
+	if err := fn(uow); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		m.logger.Warnf(apperrors.ErrDatabaseMsg(err.Error()))
+		return apperrors.ErrDatabase
+	}
+
+	return nil
+}
+
+func (u *unitOfWork) Cats() usecases.CatRepositoryInterface {
+	return u.cats
+}
+
+func (u *unitOfWork) Missions() usecases.MissionRepositoryInterface {
+	return u.missions
+}
+
+func (u *unitOfWork) Skills() usecases.SkillRepositoryInterface {
+	return u.skills
+}
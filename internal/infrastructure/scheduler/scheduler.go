@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"spyCatAgency/internal/infrastructure/logger"
+	"time"
+)
+
+// Every runs job in a background goroutine right away and then once per interval until the process exits.
+func Every(customLogger logger.Logger, name string, interval time.Duration, job func() error) {
+	run := func() {
+		if err := job(); err != nil {
+			customLogger.Warnf("Scheduled job %s failed: %s", name, err.Error())
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for range ticker.C {
+			run()
+		}
+	}()
+}
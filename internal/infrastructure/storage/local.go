@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type LocalStorage struct {
+	root string
+}
+
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{root: root}, nil
+}
+
+func (s *LocalStorage) Save(key string, data []byte) error {
+	path := s.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a half-written object.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func (s *LocalStorage) Load(key string) ([]byte, error) {
+	return os.ReadFile(s.path(key))
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *LocalStorage) path(key string) string {
+	clean := filepath.Clean("/" + strings.TrimLeft(key, "/"))
+	return filepath.Join(s.root, clean)
+}
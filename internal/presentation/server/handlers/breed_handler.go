@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	BreedUseCaseInterface interface {
+		List() ([]models.Breed, error)
+	}
+
+	breedHandler struct {
+		logger       logger.Logger
+		breedUseCase BreedUseCaseInterface
+	}
+
+	BreedResponse struct {
+		ID        string    `json:"id"`
+		Name      string    `json:"name"`
+		Origin    string    `json:"origin"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	ListBreedsResponse struct {
+		List []BreedResponse `json:"list"`
+	}
+)
+
+func NewBreedHandler(customLogger logger.Logger, breedUC BreedUseCaseInterface) *breedHandler {
+	return &breedHandler{
+		logger:       customLogger,
+		breedUseCase: breedUC,
+	}
+}
+
+func (h *breedHandler) List(ctx *gin.Context) {
+	var resp ListBreedsResponse
+
+	list, err := h.breedUseCase.List()
+
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	resp.List = make([]BreedResponse, 0)
+	for _, breed := range list {
+		resp.List = append(resp.List, BreedResponse{
+			ID:        breed.ID,
+			Name:      breed.Name,
+			Origin:    breed.Origin,
+			UpdatedAt: breed.UpdatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
@@ -8,6 +8,8 @@ import (
 	"spyCatAgency/internal/infrastructure/apperrors"
 	"spyCatAgency/internal/infrastructure/logger"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,9 +17,19 @@ import (
 type (
 	CatUseCaseInterface interface {
 		HireCat(cat models.Cat) (*models.Cat, error)
-		FireCat(catID uint) error
-		UpdateSalary(catID uint, salary float64) (*models.Cat, error)
-		List() ([]models.Cat, error)
+		ImportCats(rows []models.CatImportRow, atomic bool) (*models.CatImportReport, error)
+		FireCat(catID uint, reason string) (*models.Cat, error)
+		RehireCat(catID uint) (*models.Cat, error)
+		Update(catID uint, update models.CatUpdate) (*models.Cat, error)
+		UpdateSalary(catID uint, salary float64, reason string, effectiveAt *time.Time) (*models.SalaryChange, error)
+		SalaryHistory(catID uint) ([]models.SalaryChange, error)
+		AddLeave(leave models.Leave) (*models.Leave, error)
+		ListLeaves(catID uint) ([]models.Leave, error)
+		CancelLeave(catID, leaveID uint) error
+		Stats(catID uint) (*models.CatStats, error)
+		BulkStats(catIDs []uint) ([]models.CatStats, error)
+		Promote(catID uint, rankCode string) (*models.Cat, error)
+		List(query models.CatListQuery) (*models.CatPage, error)
 		Get(catID uint) (*models.Cat, error)
 	}
 
@@ -31,22 +43,113 @@ type (
 		YearsOfExperience uint    `json:"years_of_experience" binding:"required,numeric"`
 		Breed             string  `json:"breed" binding:"required,alpha,breed"`
 		Salary            float64 `json:"salary" binding:"required,numeric"`
+		Rank              string  `json:"rank"`
 	}
 
 	CatResponse struct {
-		ID                uint    `json:"id"`
-		Name              string  `json:"name"`
-		YearsOfExperience uint    `json:"years_of_experience"`
-		Breed             string  `json:"breed"`
-		Salary            float64 `json:"salary"`
+		ID                uint       `json:"id"`
+		Name              string     `json:"name"`
+		YearsOfExperience uint       `json:"years_of_experience"`
+		Breed             string     `json:"breed"`
+		Salary            float64    `json:"salary"`
+		Rank              string     `json:"rank"`
+		HiredAt           time.Time  `json:"hired_at"`
+		TerminatedAt      *time.Time `json:"terminated_at,omitempty"`
+		TerminationReason string     `json:"termination_reason,omitempty"`
+		PhotoURL          string     `json:"photo_url,omitempty"`
+		ThumbnailURL      string     `json:"thumbnail_url,omitempty"`
+	}
+
+	UpdateCatRequest struct {
+		Name              *string  `json:"name" binding:"omitnil,alpha"`
+		YearsOfExperience *uint    `json:"years_of_experience" binding:"omitnil,numeric,gt=0"`
+		Breed             *string  `json:"breed" binding:"omitnil,alpha,breed"`
+		Salary            *float64 `json:"salary" binding:"omitnil,numeric,gt=0"`
 	}
 
 	UpdateSalaryRequest struct {
-		Salary float64 `json:"salary" binding:"required,numeric,gt=0"`
+		Salary      float64    `json:"salary" binding:"required,numeric,gt=0"`
+		Reason      string     `json:"reason"`
+		EffectiveAt *time.Time `json:"effective_at"`
+	}
+
+	SalaryChangeResponse struct {
+		ID           uint       `json:"id"`
+		CatID        uint       `json:"cat_id"`
+		OldSalary    *float64   `json:"old_salary"`
+		NewSalary    float64    `json:"new_salary"`
+		Reason       string     `json:"reason"`
+		EffectiveAt  time.Time  `json:"effective_at"`
+		AppliedAt    *time.Time `json:"applied_at"`
+		IsApplied    bool       `json:"is_applied"`
+		CancelledAt  *time.Time `json:"cancelled_at"`
+		CancelReason string     `json:"cancel_reason,omitempty"`
+	}
+
+	SalaryHistoryResponse struct {
+		List []SalaryChangeResponse `json:"list"`
+	}
+
+	AddLeaveRequest struct {
+		StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
+		EndDate   string `json:"end_date" binding:"required,datetime=2006-01-02"`
+		Reason    string `json:"reason"`
+	}
+
+	LeaveResponse struct {
+		ID        uint   `json:"id"`
+		CatID     uint   `json:"cat_id"`
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+		Reason    string `json:"reason"`
+	}
+
+	ListLeavesResponse struct {
+		List []LeaveResponse `json:"list"`
+	}
+
+	MissionRefResponse struct {
+		ID   uint   `json:"id"`
+		Name string `json:"name"`
+	}
+
+	CatStatsResponse struct {
+		CatID                         uint                `json:"cat_id"`
+		MissionsCompleted             uint                `json:"missions_completed"`
+		TargetsCompleted              uint                `json:"targets_completed"`
+		MedianTargetCompletionSeconds *float64            `json:"median_target_completion_seconds"`
+		Countries                     []string            `json:"countries"`
+		CurrentMission                *MissionRefResponse `json:"current_mission"`
+	}
+
+	ListCatStatsResponse struct {
+		List []CatStatsResponse `json:"list"`
+	}
+
+	PromoteCatRequest struct {
+		Rank string `json:"rank"`
+	}
+
+	ListCatsRequest struct {
+		Limit                uint       `form:"limit" binding:"omitempty,min=1,max=100"`
+		Cursor               string     `form:"cursor"`
+		Breed                string     `form:"breed"`
+		MinYearsOfExperience *uint      `form:"min_years_of_experience"`
+		MaxYearsOfExperience *uint      `form:"max_years_of_experience"`
+		MinSalary            *float64   `form:"min_salary"`
+		MaxSalary            *float64   `form:"max_salary"`
+		HiredAfter           *time.Time `form:"hired_after" time_format:"2006-01-02"`
+		HiredBefore          *time.Time `form:"hired_before" time_format:"2006-01-02"`
+		Sort                 string     `form:"sort"`
+		TotalCount           bool       `form:"total_count"`
+		IncludeTerminated    bool       `form:"include_terminated"`
+		AvailableOn          *time.Time `form:"available_on" time_format:"2006-01-02"`
 	}
 
 	ListCatsResponse struct {
-		List []CatResponse `json:"list"`
+		List       []CatResponse `json:"list"`
+		NextCursor string        `json:"next_cursor,omitempty"`
+		TotalCount *uint         `json:"total_count,omitempty"`
 	}
 )
 
@@ -93,6 +196,7 @@ func (req *HireCatRequest) mapToCatObj() models.Cat {
 		YearsOfExperience: req.YearsOfExperience,
 		Breed:             req.Breed,
 		Salary:            req.Salary,
+		Rank:              req.Rank,
 	}
 }
 
@@ -102,6 +206,15 @@ func (resp *CatResponse) parseFromCatObj(cat *models.Cat) {
 	resp.YearsOfExperience = cat.YearsOfExperience
 	resp.Breed = cat.Breed
 	resp.Salary = cat.Salary
+	resp.Rank = cat.Rank
+	resp.HiredAt = cat.HiredAt
+	resp.TerminatedAt = cat.TerminatedAt
+	resp.TerminationReason = cat.TerminationReason
+
+	if cat.HasPhoto() {
+		resp.PhotoURL = fmt.Sprintf("/cats/%d/photo", cat.ID)
+		resp.ThumbnailURL = fmt.Sprintf("/cats/%d/photo?size=thumbnail", cat.ID)
+	}
 }
 
 func (h *catHandler) Fire(ctx *gin.Context) {
@@ -115,7 +228,7 @@ func (h *catHandler) Fire(ctx *gin.Context) {
 		return
 	}
 
-	err = h.catUseCase.FireCat(uint(catID))
+	firedCat, err := h.catUseCase.FireCat(uint(catID), ctx.Query("reason"))
 
 	if err != nil {
 		var httpErr *apperrors.AppError
@@ -126,7 +239,84 @@ func (h *catHandler) Fire(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
 		return
 	}
-	ctx.Status(http.StatusOK)
+
+	var resp CatResponse
+	resp.parseFromCatObj(firedCat)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *catHandler) Rehire(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	cat, err := h.catUseCase.RehireCat(uint(catID))
+
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp CatResponse
+	resp.parseFromCatObj(cat)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *catHandler) Update(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req UpdateCatRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	updatedCat, err := h.catUseCase.Update(uint(catID), req.mapToCatUpdate())
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp CatResponse
+	resp.parseFromCatObj(updatedCat)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (req *UpdateCatRequest) mapToCatUpdate() models.CatUpdate {
+	return models.CatUpdate{
+		Name:              req.Name,
+		YearsOfExperience: req.YearsOfExperience,
+		Breed:             req.Breed,
+		Salary:            req.Salary,
+	}
 }
 
 func (h *catHandler) UpdateSalary(ctx *gin.Context) {
@@ -144,12 +334,312 @@ func (h *catHandler) UpdateSalary(ctx *gin.Context) {
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Warnf("Couldn't bind request: %s", err.Error())
 		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Couldn't bind request",
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	change, err := h.catUseCase.UpdateSalary(uint(catID), req.Salary, req.Reason, req.EffectiveAt)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp SalaryChangeResponse
+	resp.parseFromSalaryChangeObj(change)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *catHandler) SalaryHistory(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	history, err := h.catUseCase.SalaryHistory(uint(catID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp SalaryHistoryResponse
+	resp.List = make([]SalaryChangeResponse, 0)
+	for _, change := range history {
+		var changeResp SalaryChangeResponse
+		changeResp.parseFromSalaryChangeObj(&change)
+		resp.List = append(resp.List, changeResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (resp *SalaryChangeResponse) parseFromSalaryChangeObj(change *models.SalaryChange) {
+	resp.ID = change.ID
+	resp.CatID = change.CatID
+	resp.OldSalary = change.OldSalary
+	resp.NewSalary = change.NewSalary
+	resp.Reason = change.Reason
+	resp.EffectiveAt = change.EffectiveAt
+	resp.AppliedAt = change.AppliedAt
+	resp.IsApplied = change.IsApplied()
+	resp.CancelledAt = change.CancelledAt
+	resp.CancelReason = change.CancelReason
+}
+
+func (h *catHandler) AddLeave(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req AddLeaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
 		})
 		return
 	}
 
-	updatedCat, err := h.catUseCase.UpdateSalary(uint(catID), req.Salary)
+	leave, err := h.catUseCase.AddLeave(req.mapToLeaveObj(uint(catID)))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp LeaveResponse
+	resp.parseFromLeaveObj(leave)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *catHandler) ListLeaves(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	leaves, err := h.catUseCase.ListLeaves(uint(catID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp ListLeavesResponse
+	resp.List = make([]LeaveResponse, 0)
+	for _, leave := range leaves {
+		var leaveResp LeaveResponse
+		leaveResp.parseFromLeaveObj(&leave)
+		resp.List = append(resp.List, leaveResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *catHandler) CancelLeave(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	leaveIDstr := ctx.Param("leaveId")
+	leaveID, err := strconv.ParseUint(leaveIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse leave id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	err = h.catUseCase.CancelLeave(uint(catID), uint(leaveID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (req *AddLeaveRequest) mapToLeaveObj(catID uint) models.Leave {
+	// Both dates were checked by the datetime binding, so parsing cannot fail here.
+	startDate, _ := time.Parse(time.DateOnly, req.StartDate)
+	endDate, _ := time.Parse(time.DateOnly, req.EndDate)
+
+	return models.Leave{
+		CatID:     catID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    req.Reason,
+	}
+}
+
+func (resp *LeaveResponse) parseFromLeaveObj(leave *models.Leave) {
+	resp.ID = leave.ID
+	resp.CatID = leave.CatID
+	resp.StartDate = leave.StartDate.Format(time.DateOnly)
+	resp.EndDate = leave.EndDate.Format(time.DateOnly)
+	resp.Reason = leave.Reason
+}
+
+func (h *catHandler) Stats(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	stats, err := h.catUseCase.Stats(uint(catID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp CatStatsResponse
+	resp.parseFromCatStatsObj(stats)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *catHandler) BulkStats(ctx *gin.Context) {
+	catIDs := make([]uint, 0)
+
+	for _, catIDstr := range strings.Split(ctx.Query("ids"), ",") {
+		if catIDstr == "" {
+			continue
+		}
+
+		catID, err := strconv.ParseUint(strings.TrimSpace(catIDstr), 10, 32)
+		if err != nil {
+			h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+			ctx.JSON(apperrors.ErrBadRequest.Status(), apperrors.ErrBadRequest.Message)
+			return
+		}
+
+		catIDs = append(catIDs, uint(catID))
+	}
+
+	list, err := h.catUseCase.BulkStats(catIDs)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp ListCatStatsResponse
+	resp.List = make([]CatStatsResponse, 0)
+	for _, stats := range list {
+		var statsResp CatStatsResponse
+		statsResp.parseFromCatStatsObj(&stats)
+		resp.List = append(resp.List, statsResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (resp *CatStatsResponse) parseFromCatStatsObj(stats *models.CatStats) {
+	resp.CatID = stats.CatID
+	resp.MissionsCompleted = stats.MissionsCompleted
+	resp.TargetsCompleted = stats.TargetsCompleted
+	resp.Countries = stats.Countries
+
+	if resp.Countries == nil {
+		resp.Countries = make([]string, 0)
+	}
+
+	if stats.MedianTargetCompletion != nil {
+		seconds := stats.MedianTargetCompletion.Seconds()
+		resp.MedianTargetCompletionSeconds = &seconds
+	}
+
+	if stats.CurrentMission != nil {
+		resp.CurrentMission = &MissionRefResponse{
+			ID:   stats.CurrentMission.ID,
+			Name: stats.CurrentMission.Name,
+		}
+	}
+}
+
+func (h *catHandler) Promote(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req PromoteCatRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			h.logger.Warnf("Couldn't bind request: %s", err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Couldn't bind request: %s", err.Error()),
+			})
+			return
+		}
+	}
+
+	cat, err := h.catUseCase.Promote(uint(catID), req.Rank)
 	if err != nil {
 		var httpErr *apperrors.AppError
 		if errors.As(err, &httpErr) {
@@ -161,14 +651,23 @@ func (h *catHandler) UpdateSalary(ctx *gin.Context) {
 	}
 
 	var resp CatResponse
-	resp.parseFromCatObj(updatedCat)
+	resp.parseFromCatObj(cat)
 	ctx.JSON(http.StatusOK, &resp)
 }
 
 func (h *catHandler) List(ctx *gin.Context) {
 	var resp ListCatsResponse
 
-	list, err := h.catUseCase.List()
+	var req ListCatsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Couldn't bind request: %s", err.Error()),
+		})
+		return
+	}
+
+	page, err := h.catUseCase.List(req.mapToCatListQuery())
 
 	if err != nil {
 		var httpErr *apperrors.AppError
@@ -181,7 +680,7 @@ func (h *catHandler) List(ctx *gin.Context) {
 	}
 
 	respList := make([]CatResponse, 0)
-	for _, cat := range list {
+	for _, cat := range page.List {
 
 		var catResp CatResponse
 		catResp.parseFromCatObj(&cat)
@@ -189,9 +688,30 @@ func (h *catHandler) List(ctx *gin.Context) {
 	}
 
 	resp.List = respList
+	resp.NextCursor = page.NextCursor
+	resp.TotalCount = page.TotalCount
 	ctx.JSON(http.StatusOK, &resp)
 }
 
+func (req *ListCatsRequest) mapToCatListQuery() models.CatListQuery {
+	return models.CatListQuery{
+		Limit:                req.Limit,
+		Cursor:               req.Cursor,
+		Breed:                req.Breed,
+		MinYearsOfExperience: req.MinYearsOfExperience,
+		MaxYearsOfExperience: req.MaxYearsOfExperience,
+		MinSalary:            req.MinSalary,
+		MaxSalary:            req.MaxSalary,
+		HiredAfter:           req.HiredAfter,
+		HiredBefore:          req.HiredBefore,
+		SortBy:               strings.TrimPrefix(req.Sort, "-"),
+		SortDesc:             strings.HasPrefix(req.Sort, "-"),
+		WithTotalCount:       req.TotalCount,
+		IncludeTerminated:    req.IncludeTerminated,
+		AvailableOn:          req.AvailableOn,
+	}
+}
+
 func (h *catHandler) Get(ctx *gin.Context) {
 	catIDstr := ctx.Param("id")
 	catID, err := strconv.ParseUint(catIDstr, 10, 32)
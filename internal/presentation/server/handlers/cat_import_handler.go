@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	ImportModeAtomic     = "atomic"
+	ImportModeBestEffort = "best_effort"
+)
+
+var requiredImportColumns = []string{"name", "years_of_experience", "breed", "salary"}
+
+type (
+	ImportCatsRequest struct {
+		Mode string `form:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	}
+
+	ImportRowResponse struct {
+		Row    uint              `json:"row"`
+		Status string            `json:"status"`
+		Cat    *CatResponse      `json:"cat,omitempty"`
+		Errors map[string]string `json:"errors,omitempty"`
+	}
+
+	ImportCatsResponse struct {
+		Mode     string              `json:"mode"`
+		Imported uint                `json:"imported"`
+		Failed   uint                `json:"failed"`
+		Rows     []ImportRowResponse `json:"rows"`
+	}
+)
+
+func (h *catHandler) Import(ctx *gin.Context) {
+	var req ImportCatsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	if req.Mode == "" {
+		req.Mode = ImportModeAtomic
+	}
+
+	var requests []HireCatRequest
+	var parseErrs []map[string]string
+	var err error
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, models.MaxImportSize)
+
+	switch ctx.ContentType() {
+	case "text/csv":
+		requests, parseErrs, err = parseCatImportCSV(body, models.MaxImportRows)
+	case binding.MIMEJSON, "":
+		requests, parseErrs, err = parseCatImportJSON(body, models.MaxImportRows)
+	default:
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Import accepts text/csv or application/json"})
+		return
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		h.logger.Warnf(apperrors.ErrBadRequestMsg("Import is too large"))
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Import must not exceed %d bytes", models.MaxImportSize),
+		})
+		return
+	}
+
+	if err != nil {
+		h.logger.Warnf("Couldn't parse import: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Couldn't parse import: %s", err.Error())})
+		return
+	}
+
+	rows := make([]models.CatImportRow, 0, len(requests))
+	for i := range requests {
+		row := models.CatImportRow{Row: uint(i + 1)}
+
+		if err := binding.Validator.ValidateStruct(&requests[i]); err != nil {
+			row.Errors = fieldErrors(&requests[i], err)
+		}
+
+		for field, msg := range parseErrs[i] {
+			if row.Errors == nil {
+				row.Errors = make(map[string]string)
+			}
+			row.Errors[field] = msg
+		}
+
+		row.Cat = requests[i].mapToCatObj()
+		rows = append(rows, row)
+	}
+
+	report, err := h.catUseCase.ImportCats(rows, req.Mode == ImportModeAtomic)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	resp := ImportCatsResponse{
+		Mode:     req.Mode,
+		Imported: report.Imported,
+		Failed:   report.Failed,
+		Rows:     make([]ImportRowResponse, 0, len(report.Rows)),
+	}
+
+	for _, row := range report.Rows {
+		rowResp := ImportRowResponse{
+			Row:    row.Row,
+			Status: row.Status,
+			Errors: row.Errors,
+		}
+		if row.Status == models.ImportStatusImported {
+			rowResp.Cat = &CatResponse{}
+			rowResp.Cat.parseFromCatObj(&row.Cat)
+		}
+		resp.Rows = append(resp.Rows, rowResp)
+	}
+
+	status := http.StatusOK
+	if report.Atomic && report.Failed > 0 {
+		status = http.StatusBadRequest
+	}
+
+	ctx.JSON(status, &resp)
+}
+
+// parseCatImportCSV reads a CSV file whose header names the HireCatRequest
+// fields. Values that cannot be converted are reported against their row
+// instead of failing the whole file. Reading stops once the file has more than
+// maxRows rows.
+func parseCatImportCSV(body io.Reader, maxRows int) ([]HireCatRequest, []map[string]string, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("file is empty")
+		}
+		return nil, nil, err
+	}
+
+	index := make(map[string]int)
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range requiredImportColumns {
+		if _, ok := index[column]; !ok {
+			return nil, nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+
+	requests := make([]HireCatRequest, 0)
+	parseErrs := make([]map[string]string, 0)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(requests) == maxRows {
+			return nil, nil, errTooManyRows(maxRows)
+		}
+
+		value := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var req HireCatRequest
+		rowErrs := make(map[string]string)
+
+		req.Name = value("name")
+		req.Breed = value("breed")
+		req.Rank = value("rank")
+
+		if v := value("years_of_experience"); v != "" {
+			years, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				rowErrs["years_of_experience"] = "must be numeric"
+			}
+			req.YearsOfExperience = uint(years)
+		}
+
+		if v := value("salary"); v != "" {
+			salary, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				rowErrs["salary"] = "must be numeric"
+			}
+			req.Salary = salary
+		}
+
+		if len(rowErrs) == 0 {
+			rowErrs = nil
+		}
+
+		requests = append(requests, req)
+		parseErrs = append(parseErrs, rowErrs)
+	}
+
+	return requests, parseErrs, nil
+}
+
+// parseCatImportJSON reads a JSON array of HireCatRequest objects one element
+// at a time, so that it can stop once the array has more than maxRows items.
+func parseCatImportJSON(body io.Reader, maxRows int) ([]HireCatRequest, []map[string]string, error) {
+	decoder := json.NewDecoder(body)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, nil, errors.New("expected a JSON array")
+	}
+
+	requests := make([]HireCatRequest, 0)
+	parseErrs := make([]map[string]string, 0)
+
+	for decoder.More() {
+		if len(requests) == maxRows {
+			return nil, nil, errTooManyRows(maxRows)
+		}
+
+		var item json.RawMessage
+		if err := decoder.Decode(&item); err != nil {
+			return nil, nil, err
+		}
+
+		var req HireCatRequest
+		var rowErrs map[string]string
+
+		if err := json.Unmarshal(item, &req); err != nil {
+			rowErrs = map[string]string{"body": err.Error()}
+		}
+
+		requests = append(requests, req)
+		parseErrs = append(parseErrs, rowErrs)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+
+	return requests, parseErrs, nil
+}
+
+func errTooManyRows(maxRows int) error {
+	return fmt.Errorf("cannot import more than %d cats at once", maxRows)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCatImportCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		maxRows  int
+		want     []HireCatRequest
+		wantErrs []map[string]string
+		wantErr  bool
+	}{
+		{
+			name:    "valid rows",
+			body:    "name,years_of_experience,breed,salary,rank\nTom,3,Bengal,1200.50,agent\nFelix,1,Siamese,900,\n",
+			maxRows: 10,
+			want: []HireCatRequest{
+				{Name: "Tom", YearsOfExperience: 3, Breed: "Bengal", Salary: 1200.50, Rank: "agent"},
+				{Name: "Felix", YearsOfExperience: 1, Breed: "Siamese", Salary: 900},
+			},
+			wantErrs: []map[string]string{nil, nil},
+		},
+		{
+			name:     "header is case insensitive and columns may be reordered",
+			body:     "Salary, Breed ,NAME,Years_Of_Experience\n500, Bengal, Tom ,2\n",
+			maxRows:  10,
+			want:     []HireCatRequest{{Name: "Tom", YearsOfExperience: 2, Breed: "Bengal", Salary: 500}},
+			wantErrs: []map[string]string{nil},
+		},
+		{
+			name:    "conversion errors are reported per row",
+			body:    "name,years_of_experience,breed,salary\nTom,three,Bengal,lots\nFelix,1,Siamese,900\n",
+			maxRows: 10,
+			want: []HireCatRequest{
+				{Name: "Tom", Breed: "Bengal"},
+				{Name: "Felix", YearsOfExperience: 1, Breed: "Siamese", Salary: 900},
+			},
+			wantErrs: []map[string]string{
+				{"years_of_experience": "must be numeric", "salary": "must be numeric"},
+				nil,
+			},
+		},
+		{
+			name:     "empty values are left for validation",
+			body:     "name,years_of_experience,breed,salary\n,,,\n",
+			maxRows:  10,
+			want:     []HireCatRequest{{}},
+			wantErrs: []map[string]string{nil},
+		},
+		{
+			name:     "header only",
+			body:     "name,years_of_experience,breed,salary\n",
+			maxRows:  10,
+			want:     []HireCatRequest{},
+			wantErrs: []map[string]string{},
+		},
+		{
+			name:    "missing column",
+			body:    "name,breed,salary\nTom,Bengal,100\n",
+			maxRows: 10,
+			wantErr: true,
+		},
+		{
+			name:    "empty file",
+			body:    "",
+			maxRows: 10,
+			wantErr: true,
+		},
+		{
+			name:    "too many rows",
+			body:    "name,years_of_experience,breed,salary\nTom,3,Bengal,100\nFelix,1,Siamese,900\n",
+			maxRows: 1,
+			wantErr: true,
+		},
+		{
+			name:    "malformed csv",
+			body:    "name,years_of_experience,breed,salary\nTom,3,\"Bengal,100\n",
+			maxRows: 10,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErrs, err := parseCatImportCSV(strings.NewReader(tt.body), tt.maxRows)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCatImportCSV() error = nil, want an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseCatImportCSV() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCatImportCSV() requests = %+v, want %+v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(gotErrs, tt.wantErrs) {
+				t.Errorf("parseCatImportCSV() row errors = %v, want %v", gotErrs, tt.wantErrs)
+			}
+		})
+	}
+}
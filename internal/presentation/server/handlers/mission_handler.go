@@ -8,6 +8,7 @@ import (
 	"spyCatAgency/internal/infrastructure/apperrors"
 	"spyCatAgency/internal/infrastructure/logger"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,16 +16,29 @@ import (
 type (
 	MissionUseCaseInterface interface {
 		Create(mission models.Mission) (*models.Mission, error)
+		Clone(id uint, clone models.MissionClone) (*models.Mission, error)
 		Assign(missionId, catID uint) (*models.Mission, error)
+		Unassign(missionID uint, reason, actor string) (*models.Mission, error)
+		Reassign(missionID, catID uint, reason, actor string) (*models.Mission, error)
+		AddMember(missionID, catID uint, role string) (*models.Mission, error)
+		RemoveMember(missionID, catID uint) error
+		SetBudget(missionID uint, amount float64, policy string) (*models.Mission, error)
+		AddExpense(expense models.Expense) (*models.ExpenseResult, error)
+		ListExpenses(missionID uint) ([]models.Expense, error)
+		ListHandovers(missionID uint) ([]models.Handover, error)
 		Get(id uint) (*models.Mission, error)
 		Delete(id uint) error
-		ListMissions() ([]models.Mission, error)
-		Update(id uint, completed bool) (*models.Mission, error)
+		ListMissions(query models.MissionListQuery) (*models.MissionPage, error)
+		ListCatMissions(query models.CatMissionsQuery) ([]models.Mission, error)
+		Update(id uint, completed bool, actor string) (*models.Mission, error)
+		SetDueAt(id uint, dueAt time.Time) (*models.Mission, error)
+		Transition(id uint, state, actor, reason string) (*models.Mission, error)
+		ListTransitions(id uint) ([]models.MissionTransition, error)
 		GetTarget(id uint) (*models.Target, error)
 		DeleteTarget(id uint) error
 		AddTarget(missionId uint, target models.Target) (*models.Target, error)
-		CompleteTarget(id uint) (*models.Target, error)
-		UpdateTargetNotes(id uint, notes string) (*models.Target, error)
+		CompleteTarget(id uint, completedBy *uint) (*models.Target, error)
+		UpdateTarget(id uint, update models.TargetUpdate) (*models.Target, error)
 	}
 
 	misionHandler struct {
@@ -33,42 +47,210 @@ type (
 	}
 
 	TargetRequest struct {
-		MissionID uint   `json:"mission_id" `
-		Name      string `json:"name" binding:"required,alpha"`
-		Country   string `json:"country" binding:"required,alpha"`
-		Notes     string `json:"notes"`
+		MissionID uint       `json:"mission_id" `
+		Name      string     `json:"name" binding:"required,alpha"`
+		Country   string     `json:"country" binding:"required,alpha"`
+		Notes     string     `json:"notes"`
+		DueAt     *time.Time `json:"due_at"`
+	}
+
+	SkillRequirementRequest struct {
+		SkillID  uint `json:"skill_id" binding:"required,gt=0"`
+		MinLevel uint `json:"min_level" binding:"required,min=1,max=5"`
 	}
 
 	AddMissionRequest struct {
-		Name       string          `json:"name" binding:"required,alpha"`
-		CatId      *uint           `json:"cat_id"`
-		TargetList []TargetRequest `json:"target_list" binding:"required"`
+		Name           string                    `json:"name" binding:"required,alpha"`
+		Type           string                    `json:"type"`
+		CatId          *uint                     `json:"cat_id"`
+		TargetList     []TargetRequest           `json:"target_list" binding:"required"`
+		RequiredSkills []SkillRequirementRequest `json:"required_skills" binding:"dive"`
+		DueAt          *time.Time                `json:"due_at"`
 	}
 
 	TargetResponse struct {
-		ID          uint   `json:"id"`
-		MissionID   uint   `json:"mission_id" `
-		Name        string `json:"name" binding:"required,alpha"`
-		Country     string `json:"country" binding:"required,alpha"`
-		Notes       string `json:"notes"`
-		IsCompleted bool   `json:"is_completed"`
+		ID            uint       `json:"id"`
+		MissionID     uint       `json:"mission_id" `
+		Name          string     `json:"name" binding:"required,alpha"`
+		Country       string     `json:"country" binding:"required,alpha"`
+		Notes         string     `json:"notes"`
+		IsCompleted   bool       `json:"is_completed"`
+		CreatedAt     time.Time  `json:"created_at"`
+		CompletedAt   *time.Time `json:"completed_at"`
+		CompletedBy   *uint      `json:"completed_by"`
+		DueAt         *time.Time `json:"due_at"`
+		TimeRemaining *int64     `json:"time_remaining_seconds"`
+	}
+
+	BudgetResponse struct {
+		Amount    float64   `json:"amount"`
+		Policy    string    `json:"policy"`
+		Spent     float64   `json:"spent"`
+		Remaining float64   `json:"remaining"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	MissionMemberResponse struct {
+		CatID    uint      `json:"cat_id"`
+		Role     string    `json:"role"`
+		JoinedAt time.Time `json:"joined_at"`
+	}
+
+	SkillRequirementResponse struct {
+		SkillID   uint   `json:"skill_id"`
+		SkillName string `json:"skill_name"`
+		MinLevel  uint   `json:"min_level"`
 	}
 
 	MissionResponse struct {
-		ID          uint             `json:"id"`
-		Name        string           `json:"name" binding:"required,alpha"`
-		CatId       *uint            `json:"cat_id"`
-		TargetList  []TargetResponse `json:"target_list" binding:"required"`
-		IsCompleted bool             `json:"is_completed"`
+		ID             uint                       `json:"id"`
+		Name           string                     `json:"name" binding:"required,alpha"`
+		Type           string                     `json:"type"`
+		CatId          *uint                      `json:"cat_id"`
+		AssignedAt     *time.Time                 `json:"assigned_at"`
+		Team           []MissionMemberResponse    `json:"team"`
+		Budget         *BudgetResponse            `json:"budget"`
+		TargetList     []TargetResponse           `json:"target_list" binding:"required"`
+		RequiredSkills []SkillRequirementResponse `json:"required_skills"`
+		State          string                     `json:"state"`
+		IsCompleted    bool                       `json:"is_completed"`
+		CreatedAt      time.Time                  `json:"created_at"`
+		CompletedAt    *time.Time                 `json:"completed_at"`
+		ClosedAt       *time.Time                 `json:"closed_at"`
+		DueAt          *time.Time                 `json:"due_at"`
+		TimeRemaining  *int64                     `json:"time_remaining_seconds"`
+		IsOverdue      bool                       `json:"is_overdue"`
+		ClonedFromID   *uint                      `json:"cloned_from_id"`
 	}
 
 	PatchRequest struct {
-		CatID       *uint `json:"cat_id,omitempty"`
-		IsCompleted *bool `json:"is_completed,omitempty" `
+		CatID       *uint      `json:"cat_id,omitempty"`
+		IsCompleted *bool      `json:"is_completed,omitempty" `
+		DueAt       *time.Time `json:"due_at,omitempty"`
+
+		// Actor is recorded on the transition made by is_completed; the
+		// system actor is recorded when it is omitted.
+		Actor string `json:"actor,omitempty"`
+	}
+
+	ListMissionsRequest struct {
+		Limit       uint       `form:"limit" binding:"omitempty,min=1,max=100"`
+		Cursor      string     `form:"cursor"`
+		Status      string     `form:"status" binding:"omitempty,oneof=active draft assigned in_progress completed aborted failed"`
+		Assigned    *bool      `form:"assigned"`
+		CatID       *uint      `form:"cat_id"`
+		Country     string     `form:"country"`
+		CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02"`
+		CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02"`
+		Overdue     *bool      `form:"overdue"`
+		DueBefore   *time.Time `form:"due_before" time_format:"2006-01-02"`
+		TotalCount  bool       `form:"total_count"`
 	}
 
 	ListMissionsResponse struct {
-		List []MissionResponse `json:"list"`
+		List       []MissionResponse `json:"list"`
+		NextCursor string            `json:"next_cursor,omitempty"`
+		TotalCount *uint             `json:"total_count,omitempty"`
+	}
+
+	TransitionRequest struct {
+		State  string `json:"state" binding:"required,oneof=draft assigned in_progress completed aborted failed"`
+		Actor  string `json:"actor" binding:"required"`
+		Reason string `json:"reason"`
+	}
+
+	TransitionResponse struct {
+		ID        uint      `json:"id"`
+		FromState string    `json:"from_state"`
+		ToState   string    `json:"to_state"`
+		Actor     string    `json:"actor"`
+		Reason    string    `json:"reason"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	ListTransitionsResponse struct {
+		List []TransitionResponse `json:"list"`
+	}
+
+	UnassignRequest struct {
+		Reason string `json:"reason" binding:"required"`
+		Actor  string `json:"actor"`
+	}
+
+	ReassignRequest struct {
+		CatID  uint   `json:"cat_id" binding:"required,gt=0"`
+		Reason string `json:"reason" binding:"required"`
+		Actor  string `json:"actor"`
+	}
+
+	AddMemberRequest struct {
+		CatID uint   `json:"cat_id" binding:"required,gt=0"`
+		Role  string `json:"role" binding:"required,oneof=lead support observer"`
+	}
+
+	SetBudgetRequest struct {
+		Amount float64 `json:"amount" binding:"required,gt=0"`
+		Policy string  `json:"policy" binding:"omitempty,oneof=warn block"`
+	}
+
+	AddExpenseRequest struct {
+		Amount     float64 `json:"amount" binding:"required,gt=0"`
+		Category   string  `json:"category" binding:"required,oneof=travel lodging equipment informants other"`
+		Date       string  `json:"date" binding:"required,datetime=2006-01-02"`
+		ReceiptRef string  `json:"receipt_ref"`
+	}
+
+	ExpenseResponse struct {
+		ID         uint      `json:"id"`
+		MissionID  uint      `json:"mission_id"`
+		Amount     float64   `json:"amount"`
+		Category   string    `json:"category"`
+		Date       string    `json:"date"`
+		ReceiptRef string    `json:"receipt_ref"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	AddExpenseResponse struct {
+		ExpenseResponse
+		OverBudget bool            `json:"over_budget"`
+		Budget     *BudgetResponse `json:"budget"`
+	}
+
+	ListExpensesResponse struct {
+		List []ExpenseResponse `json:"list"`
+	}
+
+	CloneMissionRequest struct {
+		Name      string `json:"name" binding:"omitempty,alpha"`
+		CatID     *uint  `json:"cat_id"`
+		TargetIDs []uint `json:"target_ids"`
+	}
+
+	HandoverNoteResponse struct {
+		TargetID   uint   `json:"target_id"`
+		TargetName string `json:"target_name"`
+		Notes      string `json:"notes"`
+	}
+
+	HandoverResponse struct {
+		ID             uint                   `json:"id"`
+		FromCatID      uint                   `json:"from_cat_id"`
+		ToCatID        *uint                  `json:"to_cat_id"`
+		FromAssignedAt *time.Time             `json:"from_assigned_at"`
+		HandedOverAt   time.Time              `json:"handed_over_at"`
+		Reason         string                 `json:"reason"`
+		Actor          string                 `json:"actor"`
+		Notes          []HandoverNoteResponse `json:"notes"`
+	}
+
+	ListHandoversResponse struct {
+		List []HandoverResponse `json:"list"`
+	}
+
+	ListCatMissionsRequest struct {
+		Status string     `form:"status" binding:"omitempty,oneof=active draft assigned in_progress completed aborted failed"`
+		From   *time.Time `form:"from" time_format:"2006-01-02"`
+		To     *time.Time `form:"to" time_format:"2006-01-02"`
 	}
 
 	AddTargetRequest struct {
@@ -77,7 +259,9 @@ type (
 	}
 
 	UpdateTargetRequest struct {
-		Notes *string `json:"notes,omitempty"`
+		Notes       *string    `json:"notes,omitempty"`
+		DueAt       *time.Time `json:"due_at,omitempty"`
+		CompletedBy *uint      `json:"completed_by,omitempty"`
 	}
 )
 
@@ -138,13 +322,13 @@ func (h *misionHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	if req.CatID == nil && req.IsCompleted == nil {
-		h.logger.Warnf("Bad request: cat id and is completed fields missing in patch request")
+	if req.fieldCount() == 0 {
+		h.logger.Warnf("Bad request: cat id, is completed and due at fields missing in patch request")
 		ctx.JSON(apperrors.ErrBadRequest.Status(), apperrors.ErrBadRequest.Message)
 		return
 
-	} else if req.CatID != nil && req.IsCompleted != nil {
-		h.logger.Warnf("Bad request: cat id AND is completed fields in patch request")
+	} else if req.fieldCount() > 1 {
+		h.logger.Warnf("Bad request: more than one of cat id, is completed and due at fields in patch request")
 		ctx.JSON(apperrors.ErrBadRequest.Status(), apperrors.ErrBadRequest.Message)
 		return
 	} else if req.CatID != nil {
@@ -167,7 +351,26 @@ func (h *misionHandler) Update(ctx *gin.Context) {
 
 		ctx.JSON(http.StatusOK, &resp)
 	} else if req.IsCompleted != nil {
-		mission, err := h.missionUseCase.Update(uint(missionID), *req.IsCompleted)
+		mission, err := h.missionUseCase.Update(uint(missionID), *req.IsCompleted, req.Actor)
+
+		if err != nil {
+			var httpErr *apperrors.AppError
+			if errors.As(err, &httpErr) {
+				ctx.JSON(httpErr.Status(), httpErr.Message)
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+			return
+		}
+
+		var resp MissionResponse
+
+		resp.parseFromMissionObj(*mission)
+
+		ctx.JSON(http.StatusOK, &resp)
+
+	} else if req.DueAt != nil {
+		mission, err := h.missionUseCase.SetDueAt(uint(missionID), *req.DueAt)
 
 		if err != nil {
 			var httpErr *apperrors.AppError
@@ -184,9 +387,436 @@ func (h *misionHandler) Update(ctx *gin.Context) {
 		resp.parseFromMissionObj(*mission)
 
 		ctx.JSON(http.StatusOK, &resp)
+	}
+
+}
+
+func (req *PatchRequest) fieldCount() int {
+	count := 0
+	if req.CatID != nil {
+		count++
+	}
+	if req.IsCompleted != nil {
+		count++
+	}
+	if req.DueAt != nil {
+		count++
+	}
+	return count
+}
+
+func (h *misionHandler) Transition(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req TransitionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	mission, err := h.missionUseCase.Transition(uint(missionID), req.State, req.Actor, req.Reason)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionResponse
+	resp.parseFromMissionObj(*mission)
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) ListTransitions(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	list, err := h.missionUseCase.ListTransitions(uint(missionID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp ListTransitionsResponse
+	resp.List = make([]TransitionResponse, 0)
+	for _, transition := range list {
+		resp.List = append(resp.List, TransitionResponse{
+			ID:        transition.ID,
+			FromState: transition.FromState,
+			ToState:   transition.ToState,
+			Actor:     transition.Actor,
+			Reason:    transition.Reason,
+			CreatedAt: transition.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) Unassign(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req UnassignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	mission, err := h.missionUseCase.Unassign(uint(missionID), req.Reason, req.Actor)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionResponse
+	resp.parseFromMissionObj(*mission)
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) Reassign(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req ReassignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	mission, err := h.missionUseCase.Reassign(uint(missionID), req.CatID, req.Reason, req.Actor)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionResponse
+	resp.parseFromMissionObj(*mission)
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) AddMember(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req AddMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	mission, err := h.missionUseCase.AddMember(uint(missionID), req.CatID, req.Role)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionResponse
+	resp.parseFromMissionObj(*mission)
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) RemoveMember(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
 
+	catIDstr := ctx.Param("catId")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	if err := h.missionUseCase.RemoveMember(uint(missionID), uint(catID)); err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (h *misionHandler) SetBudget(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req SetBudgetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	mission, err := h.missionUseCase.SetBudget(uint(missionID), req.Amount, req.Policy)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionResponse
+	resp.parseFromMissionObj(*mission)
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) AddExpense(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req AddExpenseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	result, err := h.missionUseCase.AddExpense(req.mapToExpenseObj(uint(missionID)))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp AddExpenseResponse
+	resp.parseFromExpenseObj(result.Expense)
+	resp.OverBudget = result.OverBudget
+	if result.Budget != nil {
+		resp.Budget = &BudgetResponse{}
+		resp.Budget.parseFromBudgetObj(*result.Budget)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) ListExpenses(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	list, err := h.missionUseCase.ListExpenses(uint(missionID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp ListExpensesResponse
+	resp.List = make([]ExpenseResponse, 0, len(list))
+	for _, expense := range list {
+		var expenseResp ExpenseResponse
+		expenseResp.parseFromExpenseObj(expense)
+		resp.List = append(resp.List, expenseResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) Clone(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req CloneMissionRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			h.logger.Warnf("Couldn't bind request: %s", err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error":  "Couldn't bind request",
+				"fields": fieldErrors(&req, err),
+			})
+			return
+		}
 	}
 
+	mission, err := h.missionUseCase.Clone(uint(missionID), models.MissionClone{
+		Name:      req.Name,
+		CatID:     req.CatID,
+		TargetIDs: req.TargetIDs,
+	})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionResponse
+	resp.parseFromMissionObj(*mission)
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *misionHandler) ListHandovers(ctx *gin.Context) {
+
+	missionIDstr := ctx.Param("id")
+	missionID, err := strconv.ParseUint(missionIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	list, err := h.missionUseCase.ListHandovers(uint(missionID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp ListHandoversResponse
+	resp.List = make([]HandoverResponse, 0)
+	for _, handover := range list {
+		var handoverResp HandoverResponse
+		handoverResp.parseFromHandoverObj(&handover)
+		resp.List = append(resp.List, handoverResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
 }
 
 func (h *misionHandler) Get(ctx *gin.Context) {
@@ -244,7 +874,17 @@ func (h *misionHandler) Delete(ctx *gin.Context) {
 func (h *misionHandler) List(ctx *gin.Context) {
 	var resp ListMissionsResponse
 
-	list, err := h.missionUseCase.ListMissions()
+	var req ListMissionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	page, err := h.missionUseCase.ListMissions(req.mapToMissionListQuery())
 	if err != nil {
 		var httpErr *apperrors.AppError
 		if errors.As(err, &httpErr) {
@@ -256,13 +896,79 @@ func (h *misionHandler) List(ctx *gin.Context) {
 	}
 
 	respList := make([]MissionResponse, 0)
-	for _, mission := range list {
+	for _, mission := range page.List {
 		var missionResp MissionResponse
 		missionResp.parseFromMissionObj(mission)
 		respList = append(respList, missionResp)
 	}
 
 	resp.List = respList
+	resp.NextCursor = page.NextCursor
+	resp.TotalCount = page.TotalCount
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (req *ListMissionsRequest) mapToMissionListQuery() models.MissionListQuery {
+	return models.MissionListQuery{
+		Limit:          req.Limit,
+		Cursor:         req.Cursor,
+		Status:         req.Status,
+		Assigned:       req.Assigned,
+		CatID:          req.CatID,
+		Country:        req.Country,
+		CreatedFrom:    req.CreatedFrom,
+		CreatedTo:      req.CreatedTo,
+		Overdue:        req.Overdue,
+		DueBefore:      req.DueBefore,
+		WithTotalCount: req.TotalCount,
+	}
+}
+
+func (h *misionHandler) ListByCat(ctx *gin.Context) {
+
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req ListCatMissionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	list, err := h.missionUseCase.ListCatMissions(models.CatMissionsQuery{
+		CatID:  uint(catID),
+		Status: req.Status,
+		From:   req.From,
+		To:     req.To,
+	})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp ListMissionsResponse
+	resp.List = make([]MissionResponse, 0)
+	for _, mission := range list {
+		var missionResp MissionResponse
+		missionResp.parseFromMissionObj(mission)
+		resp.List = append(resp.List, missionResp)
+	}
 
 	ctx.JSON(http.StatusOK, &resp)
 }
@@ -366,9 +1072,9 @@ func (h *misionHandler) UpdateTarget(ctx *gin.Context) {
 
 	}
 
-	if req.Notes == nil {
+	if req.Notes == nil && req.DueAt == nil {
 
-		target, err := h.missionUseCase.CompleteTarget(uint(targetID))
+		target, err := h.missionUseCase.CompleteTarget(uint(targetID), req.CompletedBy)
 		if err != nil {
 			var httpErr *apperrors.AppError
 			if errors.As(err, &httpErr) {
@@ -386,7 +1092,10 @@ func (h *misionHandler) UpdateTarget(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, &resp)
 	} else {
 
-		target, err := h.missionUseCase.UpdateTargetNotes(uint(targetID), *req.Notes)
+		target, err := h.missionUseCase.UpdateTarget(uint(targetID), models.TargetUpdate{
+			Notes: req.Notes,
+			DueAt: req.DueAt,
+		})
 		if err != nil {
 			var httpErr *apperrors.AppError
 			if errors.As(err, &httpErr) {
@@ -410,7 +1119,9 @@ func (req *AddMissionRequest) mapToMissionObj() *models.Mission {
 	var mission models.Mission
 
 	mission.Name = req.Name
+	mission.Type = req.Type
 	mission.CatId = req.CatId
+	mission.DueAt = req.DueAt
 
 	mission.TargetList = make([]models.Target, 0)
 
@@ -421,12 +1132,22 @@ func (req *AddMissionRequest) mapToMissionObj() *models.Mission {
 			Name:      target.Name,
 			Country:   target.Country,
 			Notes:     target.Notes,
+			DueAt:     target.DueAt,
 		}
 
 		mission.TargetList = append(mission.TargetList, targetToAppend)
 
 	}
 
+	mission.RequiredSkills = make([]models.SkillRequirement, 0)
+
+	for _, requirement := range req.RequiredSkills {
+		mission.RequiredSkills = append(mission.RequiredSkills, models.SkillRequirement{
+			SkillID:  requirement.SkillID,
+			MinLevel: requirement.MinLevel,
+		})
+	}
+
 	return &mission
 }
 
@@ -434,8 +1155,20 @@ func (resp *MissionResponse) parseFromMissionObj(mission models.Mission) {
 
 	resp.ID = mission.ID
 	resp.Name = mission.Name
+	resp.Type = mission.Type
 	resp.CatId = mission.CatId
+	resp.AssignedAt = mission.AssignedAt
+	resp.State = mission.State
 	resp.IsCompleted = mission.IsCompleted
+	resp.CreatedAt = mission.CreatedAt
+	resp.CompletedAt = mission.CompletedAt
+	resp.ClosedAt = mission.ClosedAt
+	resp.DueAt = mission.DueAt
+	resp.ClonedFromID = mission.ClonedFromID
+	resp.IsOverdue = mission.IsOverdue(time.Now())
+	if !mission.IsClosed() {
+		resp.TimeRemaining = timeRemaining(mission.DueAt)
+	}
 
 	targetResponseList := make([]TargetResponse, 0)
 
@@ -448,6 +1181,13 @@ func (resp *MissionResponse) parseFromMissionObj(mission models.Mission) {
 			Country:     target.Country,
 			Notes:       target.Notes,
 			IsCompleted: target.IsCompleted,
+			CreatedAt:   target.CreatedAt,
+			CompletedAt: target.CompletedAt,
+			CompletedBy: target.CompletedBy,
+			DueAt:       target.DueAt,
+		}
+		if !target.IsCompleted {
+			targetToAppend.TimeRemaining = timeRemaining(target.DueAt)
 		}
 		targetResponseList = append(targetResponseList, targetToAppend)
 
@@ -455,6 +1195,31 @@ func (resp *MissionResponse) parseFromMissionObj(mission models.Mission) {
 
 	resp.TargetList = targetResponseList
 
+	if mission.Budget != nil {
+		resp.Budget = &BudgetResponse{}
+		resp.Budget.parseFromBudgetObj(*mission.Budget)
+	}
+
+	resp.Team = make([]MissionMemberResponse, 0, len(mission.Team))
+
+	for _, member := range mission.Team {
+		resp.Team = append(resp.Team, MissionMemberResponse{
+			CatID:    member.CatID,
+			Role:     member.Role,
+			JoinedAt: member.JoinedAt,
+		})
+	}
+
+	resp.RequiredSkills = make([]SkillRequirementResponse, 0)
+
+	for _, requirement := range mission.RequiredSkills {
+		resp.RequiredSkills = append(resp.RequiredSkills, SkillRequirementResponse{
+			SkillID:   requirement.SkillID,
+			SkillName: requirement.SkillName,
+			MinLevel:  requirement.MinLevel,
+		})
+	}
+
 }
 
 func (req *TargetRequest) mapToTargetObj() *models.Target {
@@ -463,6 +1228,7 @@ func (req *TargetRequest) mapToTargetObj() *models.Target {
 		Name:      req.Name,
 		Country:   req.Country,
 		Notes:     req.Notes,
+		DueAt:     req.DueAt,
 	}
 }
 
@@ -473,4 +1239,70 @@ func (resp *TargetResponse) parseFromTargetObj(target models.Target) {
 	resp.Country = target.Country
 	resp.Notes = target.Notes
 	resp.IsCompleted = target.IsCompleted
+	resp.CreatedAt = target.CreatedAt
+	resp.CompletedAt = target.CompletedAt
+	resp.CompletedBy = target.CompletedBy
+	resp.DueAt = target.DueAt
+	if !target.IsCompleted {
+		resp.TimeRemaining = timeRemaining(target.DueAt)
+	}
+}
+
+func (req *AddExpenseRequest) mapToExpenseObj(missionID uint) models.Expense {
+	spentOn, _ := time.Parse(time.DateOnly, req.Date)
+
+	return models.Expense{
+		MissionID:  missionID,
+		Amount:     req.Amount,
+		Category:   req.Category,
+		SpentOn:    spentOn,
+		ReceiptRef: req.ReceiptRef,
+	}
+}
+
+func (resp *ExpenseResponse) parseFromExpenseObj(expense models.Expense) {
+	resp.ID = expense.ID
+	resp.MissionID = expense.MissionID
+	resp.Amount = expense.Amount
+	resp.Category = expense.Category
+	resp.Date = expense.SpentOn.Format(time.DateOnly)
+	resp.ReceiptRef = expense.ReceiptRef
+	resp.CreatedAt = expense.CreatedAt
+}
+
+func (resp *BudgetResponse) parseFromBudgetObj(budget models.MissionBudget) {
+	resp.Amount = budget.Amount
+	resp.Policy = budget.Policy
+	resp.Spent = budget.Spent
+	resp.Remaining = budget.Remaining()
+	resp.UpdatedAt = budget.UpdatedAt
+}
+
+// timeRemaining returns whole seconds until dueAt, negative once it has passed.
+func timeRemaining(dueAt *time.Time) *int64 {
+	if dueAt == nil {
+		return nil
+	}
+
+	seconds := int64(time.Until(*dueAt) / time.Second)
+	return &seconds
+}
+
+func (resp *HandoverResponse) parseFromHandoverObj(handover *models.Handover) {
+	resp.ID = handover.ID
+	resp.FromCatID = handover.FromCatID
+	resp.ToCatID = handover.ToCatID
+	resp.FromAssignedAt = handover.FromAssignedAt
+	resp.HandedOverAt = handover.CreatedAt
+	resp.Reason = handover.Reason
+	resp.Actor = handover.Actor
+
+	resp.Notes = make([]HandoverNoteResponse, 0)
+	for _, note := range handover.Notes {
+		resp.Notes = append(resp.Notes, HandoverNoteResponse{
+			TargetID:   note.TargetID,
+			TargetName: note.TargetName,
+			Notes:      note.Notes,
+		})
+	}
 }
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	MissionTemplateUseCaseInterface interface {
+		Create(template models.MissionTemplate) (*models.MissionTemplate, error)
+		Get(id uint) (*models.MissionTemplate, error)
+		List() ([]models.MissionTemplate, error)
+		Update(template models.MissionTemplate) (*models.MissionTemplate, error)
+		Delete(id uint) error
+		Instantiate(id uint, instance models.TemplateInstance) (*models.Mission, error)
+	}
+
+	missionTemplateHandler struct {
+		logger                 logger.Logger
+		missionTemplateUseCase MissionTemplateUseCaseInterface
+	}
+
+	TemplateTargetRequest struct {
+		Name    string `json:"name" binding:"required,alpha"`
+		Country string `json:"country" binding:"required,alpha"`
+		Notes   string `json:"notes"`
+	}
+
+	MissionTemplateRequest struct {
+		Name        string                  `json:"name" binding:"required"`
+		NamePattern string                  `json:"name_pattern" binding:"required"`
+		MissionType string                  `json:"mission_type"`
+		Notes       string                  `json:"notes"`
+		Targets     []TemplateTargetRequest `json:"targets" binding:"required,min=1,dive"`
+	}
+
+	InstantiateTemplateRequest struct {
+		Name  string     `json:"name" binding:"omitempty,alpha"`
+		CatID *uint      `json:"cat_id"`
+		DueAt *time.Time `json:"due_at"`
+	}
+
+	TemplateTargetResponse struct {
+		Name    string `json:"name"`
+		Country string `json:"country"`
+		Notes   string `json:"notes"`
+	}
+
+	MissionTemplateResponse struct {
+		ID          uint                     `json:"id"`
+		Name        string                   `json:"name"`
+		NamePattern string                   `json:"name_pattern"`
+		MissionType string                   `json:"mission_type"`
+		Notes       string                   `json:"notes"`
+		Targets     []TemplateTargetResponse `json:"targets"`
+		CreatedAt   time.Time                `json:"created_at"`
+		UpdatedAt   time.Time                `json:"updated_at"`
+	}
+
+	ListMissionTemplatesResponse struct {
+		List []MissionTemplateResponse `json:"list"`
+	}
+)
+
+func NewMissionTemplateHandler(customLogger logger.Logger, missionTemplateUC MissionTemplateUseCaseInterface) *missionTemplateHandler {
+	return &missionTemplateHandler{
+		logger:                 customLogger,
+		missionTemplateUseCase: missionTemplateUC,
+	}
+}
+
+func (h *missionTemplateHandler) Create(ctx *gin.Context) {
+	var req MissionTemplateRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	template, err := h.missionTemplateUseCase.Create(req.mapToTemplateObj())
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionTemplateResponse
+	resp.parseFromTemplateObj(*template)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *missionTemplateHandler) Get(ctx *gin.Context) {
+	templateIDstr := ctx.Param("id")
+	templateID, err := strconv.ParseUint(templateIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission template id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	template, err := h.missionTemplateUseCase.Get(uint(templateID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionTemplateResponse
+	resp.parseFromTemplateObj(*template)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *missionTemplateHandler) List(ctx *gin.Context) {
+	var resp ListMissionTemplatesResponse
+
+	list, err := h.missionTemplateUseCase.List()
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	resp.List = make([]MissionTemplateResponse, 0)
+	for _, template := range list {
+		var templateResp MissionTemplateResponse
+		templateResp.parseFromTemplateObj(template)
+		resp.List = append(resp.List, templateResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *missionTemplateHandler) Update(ctx *gin.Context) {
+	templateIDstr := ctx.Param("id")
+	templateID, err := strconv.ParseUint(templateIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission template id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req MissionTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	template := req.mapToTemplateObj()
+	template.ID = uint(templateID)
+
+	updated, err := h.missionTemplateUseCase.Update(template)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionTemplateResponse
+	resp.parseFromTemplateObj(*updated)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *missionTemplateHandler) Delete(ctx *gin.Context) {
+	templateIDstr := ctx.Param("id")
+	templateID, err := strconv.ParseUint(templateIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission template id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	if err := h.missionTemplateUseCase.Delete(uint(templateID)); err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (h *missionTemplateHandler) Instantiate(ctx *gin.Context) {
+	templateIDstr := ctx.Param("id")
+	templateID, err := strconv.ParseUint(templateIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse mission template id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req InstantiateTemplateRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			h.logger.Warnf("Couldn't bind request: %s", err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Couldn't bind request: %s", err.Error()),
+			})
+			return
+		}
+	}
+
+	mission, err := h.missionTemplateUseCase.Instantiate(uint(templateID), models.TemplateInstance{
+		Name:  req.Name,
+		CatID: req.CatID,
+		DueAt: req.DueAt,
+	})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionResponse
+	resp.parseFromMissionObj(*mission)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (req *MissionTemplateRequest) mapToTemplateObj() models.MissionTemplate {
+	template := models.MissionTemplate{
+		Name:        req.Name,
+		NamePattern: req.NamePattern,
+		MissionType: req.MissionType,
+		Notes:       req.Notes,
+		Targets:     make([]models.TemplateTarget, 0, len(req.Targets)),
+	}
+
+	for _, target := range req.Targets {
+		template.Targets = append(template.Targets, models.TemplateTarget{
+			Name:    target.Name,
+			Country: target.Country,
+			Notes:   target.Notes,
+		})
+	}
+
+	return template
+}
+
+func (resp *MissionTemplateResponse) parseFromTemplateObj(template models.MissionTemplate) {
+	resp.ID = template.ID
+	resp.Name = template.Name
+	resp.NamePattern = template.NamePattern
+	resp.MissionType = template.MissionType
+	resp.Notes = template.Notes
+	resp.CreatedAt = template.CreatedAt
+	resp.UpdatedAt = template.UpdatedAt
+
+	resp.Targets = make([]TemplateTargetResponse, 0, len(template.Targets))
+	for _, target := range template.Targets {
+		resp.Targets = append(resp.Targets, TemplateTargetResponse{
+			Name:    target.Name,
+			Country: target.Country,
+			Notes:   target.Notes,
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	MissionTypeUseCaseInterface interface {
+		List() ([]models.MissionType, error)
+		Create(missionType models.MissionType) (*models.MissionType, error)
+		Update(code string, update models.MissionTypeUpdate) (*models.MissionType, error)
+	}
+
+	missionTypeHandler struct {
+		logger             logger.Logger
+		missionTypeUseCase MissionTypeUseCaseInterface
+	}
+
+	MissionTypeResponse struct {
+		Code                        string `json:"code"`
+		Name                        string `json:"name"`
+		MinTargets                  uint   `json:"min_targets"`
+		MaxTargets                  uint   `json:"max_targets"`
+		AllowDeleteCompletedTargets bool   `json:"allow_delete_completed_targets"`
+		AllowDeleteAssigned         bool   `json:"allow_delete_assigned"`
+	}
+
+	ListMissionTypesResponse struct {
+		List []MissionTypeResponse `json:"list"`
+	}
+
+	CreateMissionTypeRequest struct {
+		Code                        string `json:"code" binding:"required"`
+		Name                        string `json:"name" binding:"required"`
+		MinTargets                  uint   `json:"min_targets" binding:"required,min=1"`
+		MaxTargets                  uint   `json:"max_targets" binding:"required,min=1"`
+		AllowDeleteCompletedTargets bool   `json:"allow_delete_completed_targets"`
+		AllowDeleteAssigned         bool   `json:"allow_delete_assigned"`
+	}
+
+	UpdateMissionTypeRequest struct {
+		Name                        *string `json:"name" binding:"omitnil,min=1"`
+		MinTargets                  *uint   `json:"min_targets" binding:"omitnil,min=1"`
+		MaxTargets                  *uint   `json:"max_targets" binding:"omitnil,min=1"`
+		AllowDeleteCompletedTargets *bool   `json:"allow_delete_completed_targets"`
+		AllowDeleteAssigned         *bool   `json:"allow_delete_assigned"`
+	}
+)
+
+func NewMissionTypeHandler(customLogger logger.Logger, missionTypeUC MissionTypeUseCaseInterface) *missionTypeHandler {
+	return &missionTypeHandler{
+		logger:             customLogger,
+		missionTypeUseCase: missionTypeUC,
+	}
+}
+
+func (h *missionTypeHandler) List(ctx *gin.Context) {
+	var resp ListMissionTypesResponse
+
+	list, err := h.missionTypeUseCase.List()
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	resp.List = make([]MissionTypeResponse, 0)
+	for _, missionType := range list {
+		var missionTypeResp MissionTypeResponse
+		missionTypeResp.parseFromMissionTypeObj(&missionType)
+		resp.List = append(resp.List, missionTypeResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *missionTypeHandler) Create(ctx *gin.Context) {
+	var req CreateMissionTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	missionType, err := h.missionTypeUseCase.Create(models.MissionType{
+		Code:                        req.Code,
+		Name:                        req.Name,
+		MinTargets:                  req.MinTargets,
+		MaxTargets:                  req.MaxTargets,
+		AllowDeleteCompletedTargets: req.AllowDeleteCompletedTargets,
+		AllowDeleteAssigned:         req.AllowDeleteAssigned,
+	})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionTypeResponse
+	resp.parseFromMissionTypeObj(missionType)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *missionTypeHandler) Update(ctx *gin.Context) {
+	var req UpdateMissionTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	missionType, err := h.missionTypeUseCase.Update(ctx.Param("code"), models.MissionTypeUpdate{
+		Name:                        req.Name,
+		MinTargets:                  req.MinTargets,
+		MaxTargets:                  req.MaxTargets,
+		AllowDeleteCompletedTargets: req.AllowDeleteCompletedTargets,
+		AllowDeleteAssigned:         req.AllowDeleteAssigned,
+	})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp MissionTypeResponse
+	resp.parseFromMissionTypeObj(missionType)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (resp *MissionTypeResponse) parseFromMissionTypeObj(missionType *models.MissionType) {
+	resp.Code = missionType.Code
+	resp.Name = missionType.Name
+	resp.MinTargets = missionType.MinTargets
+	resp.MaxTargets = missionType.MaxTargets
+	resp.AllowDeleteCompletedTargets = missionType.AllowDeleteCompletedTargets
+	resp.AllowDeleteAssigned = missionType.AllowDeleteAssigned
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	PayrollUseCaseInterface interface {
+		CreateRun(start, end time.Time) (*models.PayrollRun, error)
+		GetRun(id uint) (*models.PayrollRun, error)
+	}
+
+	payrollHandler struct {
+		logger         logger.Logger
+		payrollUseCase PayrollUseCaseInterface
+	}
+
+	CreatePayrollRunRequest struct {
+		PeriodStart string `json:"period_start" binding:"required,datetime=2006-01-02"`
+		PeriodEnd   string `json:"period_end" binding:"required,datetime=2006-01-02"`
+	}
+
+	PayrollLineResponse struct {
+		CatID        uint    `json:"cat_id"`
+		CatName      string  `json:"cat_name"`
+		Salary       float64 `json:"salary"`
+		DaysEmployed uint    `json:"days_employed"`
+		DaysInPeriod uint    `json:"days_in_period"`
+		Amount       float64 `json:"amount"`
+	}
+
+	PayrollRunResponse struct {
+		ID          uint                  `json:"id"`
+		PeriodStart string                `json:"period_start"`
+		PeriodEnd   string                `json:"period_end"`
+		Total       float64               `json:"total"`
+		Lines       []PayrollLineResponse `json:"lines"`
+		CreatedAt   time.Time             `json:"created_at"`
+	}
+)
+
+func NewPayrollHandler(customLogger logger.Logger, payrollUC PayrollUseCaseInterface) *payrollHandler {
+	return &payrollHandler{
+		logger:         customLogger,
+		payrollUseCase: payrollUC,
+	}
+}
+
+func (h *payrollHandler) CreateRun(ctx *gin.Context) {
+	var req CreatePayrollRunRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	// Both dates were checked by the datetime binding, so parsing cannot fail here.
+	periodStart, _ := time.Parse(time.DateOnly, req.PeriodStart)
+	periodEnd, _ := time.Parse(time.DateOnly, req.PeriodEnd)
+
+	run, err := h.payrollUseCase.CreateRun(periodStart, periodEnd)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp PayrollRunResponse
+	resp.parseFromPayrollRunObj(run)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *payrollHandler) GetRun(ctx *gin.Context) {
+	run, ok := h.getRun(ctx)
+	if !ok {
+		return
+	}
+
+	var resp PayrollRunResponse
+	resp.parseFromPayrollRunObj(run)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *payrollHandler) ExportRun(ctx *gin.Context) {
+	run, ok := h.getRun(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=payroll_%d_%s_%s.csv",
+		run.ID, run.PeriodStart.Format(time.DateOnly), run.PeriodEnd.Format(time.DateOnly)))
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Status(http.StatusOK)
+
+	writer := csv.NewWriter(ctx.Writer)
+	writer.Write([]string{"cat_id", "cat_name", "salary", "days_employed", "days_in_period", "amount"})
+
+	for _, line := range run.Lines {
+		writer.Write([]string{
+			strconv.FormatUint(uint64(line.CatID), 10),
+			line.CatName,
+			strconv.FormatFloat(line.Salary, 'f', 2, 64),
+			strconv.FormatUint(uint64(line.DaysEmployed), 10),
+			strconv.FormatUint(uint64(line.DaysInPeriod), 10),
+			strconv.FormatFloat(line.Amount, 'f', 2, 64),
+		})
+	}
+
+	writer.Write([]string{"", "total", "", "", "", strconv.FormatFloat(run.Total, 'f', 2, 64)})
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		h.logger.Warnf("Failed to write payroll export: %s", err.Error())
+	}
+}
+
+func (h *payrollHandler) getRun(ctx *gin.Context) (*models.PayrollRun, bool) {
+	runIDstr := ctx.Param("id")
+	runID, err := strconv.ParseUint(runIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse payroll run id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return nil, false
+	}
+
+	run, err := h.payrollUseCase.GetRun(uint(runID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return nil, false
+	}
+
+	return run, true
+}
+
+func (resp *PayrollRunResponse) parseFromPayrollRunObj(run *models.PayrollRun) {
+	resp.ID = run.ID
+	resp.PeriodStart = run.PeriodStart.Format(time.DateOnly)
+	resp.PeriodEnd = run.PeriodEnd.Format(time.DateOnly)
+	resp.Total = run.Total
+	resp.CreatedAt = run.CreatedAt
+
+	resp.Lines = make([]PayrollLineResponse, 0)
+	for _, line := range run.Lines {
+		resp.Lines = append(resp.Lines, PayrollLineResponse{
+			CatID:        line.CatID,
+			CatName:      line.CatName,
+			Salary:       line.Salary,
+			DaysEmployed: line.DaysEmployed,
+			DaysInPeriod: line.DaysInPeriod,
+			Amount:       line.Amount,
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	PhotoUseCaseInterface interface {
+		Upload(catID uint, data []byte) (*models.Cat, error)
+		Get(catID uint, thumb bool) ([]byte, string, error)
+	}
+
+	photoHandler struct {
+		logger       logger.Logger
+		photoUseCase PhotoUseCaseInterface
+	}
+)
+
+func NewPhotoHandler(customLogger logger.Logger, photoUC PhotoUseCaseInterface) *photoHandler {
+	return &photoHandler{
+		logger:       customLogger,
+		photoUseCase: photoUC,
+	}
+}
+
+func (h *photoHandler) Upload(ctx *gin.Context) {
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, models.MaxPhotoRequestSize)
+
+	fileHeader, err := ctx.FormFile("photo")
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		h.logger.Warnf(apperrors.ErrBadRequestMsg("Photo is too large"))
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Photo must not exceed %d bytes", models.MaxPhotoSize),
+		})
+		return
+	}
+
+	if err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Couldn't bind request: %s", err.Error()),
+		})
+		return
+	}
+
+	if fileHeader.Size > models.MaxPhotoSize {
+		h.logger.Warnf(apperrors.ErrBadRequestMsg("Photo is too large"))
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Photo must not exceed %d bytes", models.MaxPhotoSize),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Warnf("Failed to open uploaded photo: %s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, models.MaxPhotoSize+1))
+	if err != nil {
+		h.logger.Warnf("Failed to read uploaded photo: %s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	cat, err := h.photoUseCase.Upload(uint(catID), data)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp CatResponse
+	resp.parseFromCatObj(cat)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *photoHandler) Get(ctx *gin.Context) {
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	data, contentType, err := h.photoUseCase.Get(uint(catID), ctx.Query("size") == "thumbnail")
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, data)
+}
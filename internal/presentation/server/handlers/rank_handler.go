@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	RankUseCaseInterface interface {
+		List() ([]models.Rank, error)
+		Update(code string, update models.RankUpdate) (*models.Rank, error)
+	}
+
+	rankHandler struct {
+		logger      logger.Logger
+		rankUseCase RankUseCaseInterface
+	}
+
+	RankResponse struct {
+		Code                 string  `json:"code"`
+		Name                 string  `json:"name"`
+		Position             uint    `json:"position"`
+		MinYearsOfExperience uint    `json:"min_years_of_experience"`
+		MinCompletedMissions uint    `json:"min_completed_missions"`
+		MinSalary            float64 `json:"min_salary"`
+		MaxSalary            float64 `json:"max_salary"`
+	}
+
+	ListRanksResponse struct {
+		List []RankResponse `json:"list"`
+	}
+
+	UpdateRankRequest struct {
+		Name                 *string  `json:"name" binding:"omitnil,min=1"`
+		MinYearsOfExperience *uint    `json:"min_years_of_experience"`
+		MinCompletedMissions *uint    `json:"min_completed_missions"`
+		MinSalary            *float64 `json:"min_salary" binding:"omitnil,gte=0"`
+		MaxSalary            *float64 `json:"max_salary" binding:"omitnil,gt=0"`
+	}
+)
+
+func NewRankHandler(customLogger logger.Logger, rankUC RankUseCaseInterface) *rankHandler {
+	return &rankHandler{
+		logger:      customLogger,
+		rankUseCase: rankUC,
+	}
+}
+
+func (h *rankHandler) List(ctx *gin.Context) {
+	var resp ListRanksResponse
+
+	list, err := h.rankUseCase.List()
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	resp.List = make([]RankResponse, 0)
+	for _, rank := range list {
+		var rankResp RankResponse
+		rankResp.parseFromRankObj(&rank)
+		resp.List = append(resp.List, rankResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *rankHandler) Update(ctx *gin.Context) {
+	var req UpdateRankRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	rank, err := h.rankUseCase.Update(ctx.Param("code"), models.RankUpdate{
+		Name:                 req.Name,
+		MinYearsOfExperience: req.MinYearsOfExperience,
+		MinCompletedMissions: req.MinCompletedMissions,
+		MinSalary:            req.MinSalary,
+		MaxSalary:            req.MaxSalary,
+	})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp RankResponse
+	resp.parseFromRankObj(rank)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (resp *RankResponse) parseFromRankObj(rank *models.Rank) {
+	resp.Code = rank.Code
+	resp.Name = rank.Name
+	resp.Position = rank.Position
+	resp.MinYearsOfExperience = rank.MinYearsOfExperience
+	resp.MinCompletedMissions = rank.MinCompletedMissions
+	resp.MinSalary = rank.MinSalary
+	resp.MaxSalary = rank.MaxSalary
+}
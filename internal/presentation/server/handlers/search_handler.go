@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SearchUseCaseInterface interface {
+		Search(query models.SearchQuery) ([]models.SearchHit, error)
+	}
+
+	searchHandler struct {
+		logger        logger.Logger
+		searchUseCase SearchUseCaseInterface
+	}
+
+	SearchRequest struct {
+		Q     string   `form:"q" binding:"required"`
+		Types []string `form:"type" binding:"omitempty,dive,oneof=cat mission target"`
+		Limit uint     `form:"limit" binding:"omitempty,min=1,max=100"`
+	}
+
+	SearchHitResponse struct {
+		Type      string  `json:"type"`
+		ID        uint    `json:"id"`
+		MissionID *uint   `json:"mission_id,omitempty"`
+		Title     string  `json:"title"`
+		Snippet   string  `json:"snippet"`
+		Rank      float64 `json:"rank"`
+	}
+
+	SearchResponse struct {
+		List []SearchHitResponse `json:"list"`
+	}
+)
+
+func NewSearchHandler(customLogger logger.Logger, searchUC SearchUseCaseInterface) *searchHandler {
+	return &searchHandler{
+		logger:        customLogger,
+		searchUseCase: searchUC,
+	}
+}
+
+func (h *searchHandler) Search(ctx *gin.Context) {
+	var req SearchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	hits, err := h.searchUseCase.Search(models.SearchQuery{
+		Text:  req.Q,
+		Types: req.Types,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp SearchResponse
+	resp.List = make([]SearchHitResponse, 0)
+	for _, hit := range hits {
+		var hitResp SearchHitResponse
+		hitResp.parseFromSearchHitObj(&hit)
+		resp.List = append(resp.List, hitResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (resp *SearchHitResponse) parseFromSearchHitObj(hit *models.SearchHit) {
+	resp.Type = hit.Type
+	resp.ID = hit.ID
+	resp.MissionID = hit.MissionID
+	resp.Title = hit.Title
+	resp.Snippet = hit.Snippet
+	resp.Rank = hit.Rank
+}
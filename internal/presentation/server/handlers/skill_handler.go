@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"spyCatAgency/internal/domain/models"
+	"spyCatAgency/internal/infrastructure/apperrors"
+	"spyCatAgency/internal/infrastructure/logger"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SkillUseCaseInterface interface {
+		Create(skill models.Skill) (*models.Skill, error)
+		List() ([]models.Skill, error)
+		ListCatSkills(catID uint) ([]models.CatSkill, error)
+		SetCatSkill(catID, skillID, level uint) ([]models.CatSkill, error)
+		RemoveCatSkill(catID, skillID uint) error
+	}
+
+	skillHandler struct {
+		logger       logger.Logger
+		skillUseCase SkillUseCaseInterface
+	}
+
+	CreateSkillRequest struct {
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
+	}
+
+	SkillResponse struct {
+		ID          uint   `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	ListSkillsResponse struct {
+		List []SkillResponse `json:"list"`
+	}
+
+	SetCatSkillRequest struct {
+		Level uint `json:"level" binding:"required,min=1,max=5"`
+	}
+
+	CatSkillResponse struct {
+		SkillID   uint   `json:"skill_id"`
+		SkillName string `json:"skill_name"`
+		Level     uint   `json:"level"`
+	}
+
+	ListCatSkillsResponse struct {
+		List []CatSkillResponse `json:"list"`
+	}
+)
+
+func NewSkillHandler(customLogger logger.Logger, skillUC SkillUseCaseInterface) *skillHandler {
+	return &skillHandler{
+		logger:       customLogger,
+		skillUseCase: skillUC,
+	}
+}
+
+func (h *skillHandler) Create(ctx *gin.Context) {
+	var req CreateSkillRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Couldn't bind request: %s", err.Error()),
+		})
+		return
+	}
+
+	skill, err := h.skillUseCase.Create(models.Skill{Name: req.Name, Description: req.Description})
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	var resp SkillResponse
+	resp.parseFromSkillObj(*skill)
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *skillHandler) List(ctx *gin.Context) {
+	var resp ListSkillsResponse
+
+	list, err := h.skillUseCase.List()
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	resp.List = make([]SkillResponse, 0)
+	for _, skill := range list {
+		var skillResp SkillResponse
+		skillResp.parseFromSkillObj(skill)
+		resp.List = append(resp.List, skillResp)
+	}
+
+	ctx.JSON(http.StatusOK, &resp)
+}
+
+func (h *skillHandler) ListCatSkills(ctx *gin.Context) {
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	list, err := h.skillUseCase.ListCatSkills(uint(catID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newListCatSkillsResponse(list))
+}
+
+func (h *skillHandler) SetCatSkill(ctx *gin.Context) {
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	skillIDstr := ctx.Param("skillId")
+	skillID, err := strconv.ParseUint(skillIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse skill id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	var req SetCatSkillRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnf("Couldn't bind request: %s", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":  "Couldn't bind request",
+			"fields": fieldErrors(&req, err),
+		})
+		return
+	}
+
+	list, err := h.skillUseCase.SetCatSkill(uint(catID), uint(skillID), req.Level)
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newListCatSkillsResponse(list))
+}
+
+func (h *skillHandler) RemoveCatSkill(ctx *gin.Context) {
+	catIDstr := ctx.Param("id")
+	catID, err := strconv.ParseUint(catIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse cat id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	skillIDstr := ctx.Param("skillId")
+	skillID, err := strconv.ParseUint(skillIDstr, 10, 32)
+
+	if err != nil {
+		h.logger.Warnf("Failed to parse skill id to integer:%s", err.Error())
+		ctx.JSON(apperrors.ErrInternal.Status(), apperrors.ErrInternal.Message)
+		return
+	}
+
+	err = h.skillUseCase.RemoveCatSkill(uint(catID), uint(skillID))
+	if err != nil {
+		var httpErr *apperrors.AppError
+		if errors.As(err, &httpErr) {
+			ctx.JSON(httpErr.Status(), httpErr.Message)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (resp *SkillResponse) parseFromSkillObj(skill models.Skill) {
+	resp.ID = skill.ID
+	resp.Name = skill.Name
+	resp.Description = skill.Description
+}
+
+func newListCatSkillsResponse(list []models.CatSkill) *ListCatSkillsResponse {
+	resp := ListCatSkillsResponse{List: make([]CatSkillResponse, 0)}
+	for _, catSkill := range list {
+		resp.List = append(resp.List, CatSkillResponse{
+			SkillID:   catSkill.SkillID,
+			SkillName: catSkill.SkillName,
+			Level:     catSkill.Level,
+		})
+	}
+	return &resp
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func fieldErrors(req interface{}, err error) map[string]string {
+	res := make(map[string]string)
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		res["body"] = err.Error()
+		return res
+	}
+
+	reqType := reflect.TypeOf(req)
+	for reqType.Kind() == reflect.Ptr {
+		reqType = reqType.Elem()
+	}
+
+	for _, fieldErr := range validationErrs {
+		name := fieldErr.Field()
+		if field, ok := reqType.FieldByName(fieldErr.StructField()); ok {
+			if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+				name = tag
+			} else if tag := field.Tag.Get("form"); tag != "" {
+				name = tag
+			}
+		}
+		res[name] = fieldErrorMessage(fieldErr)
+	}
+
+	return res
+}
+
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "alpha":
+		return "must contain only letters"
+	case "numeric":
+		return "must be numeric"
+	case "breed":
+		return "is not a known breed"
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fieldErr.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fieldErr.Param())
+	default:
+		return fmt.Sprintf("failed on '%s' validation", fieldErr.Tag())
+	}
+}
@@ -1,11 +1,7 @@
 package server
 
 import (
-	"encoding/json"
-	"io"
-	"net/http"
 	"spyCatAgency/internal/infrastructure/logger"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -15,8 +11,18 @@ import (
 type (
 	CatHandlerInterface interface {
 		Hire(ctx *gin.Context)
+		Import(ctx *gin.Context)
 		Fire(ctx *gin.Context)
+		Rehire(ctx *gin.Context)
+		Update(ctx *gin.Context)
 		UpdateSalary(ctx *gin.Context)
+		SalaryHistory(ctx *gin.Context)
+		AddLeave(ctx *gin.Context)
+		ListLeaves(ctx *gin.Context)
+		CancelLeave(ctx *gin.Context)
+		Stats(ctx *gin.Context)
+		BulkStats(ctx *gin.Context)
+		Promote(ctx *gin.Context)
 		List(ctx *gin.Context)
 		Get(ctx *gin.Context)
 	}
@@ -24,30 +30,111 @@ type (
 	MissionHandlerInterface interface {
 		Add(ctx *gin.Context)
 		Update(ctx *gin.Context)
+		Transition(ctx *gin.Context)
+		Unassign(ctx *gin.Context)
+		Reassign(ctx *gin.Context)
+		AddMember(ctx *gin.Context)
+		RemoveMember(ctx *gin.Context)
+		SetBudget(ctx *gin.Context)
+		AddExpense(ctx *gin.Context)
+		ListExpenses(ctx *gin.Context)
+		Clone(ctx *gin.Context)
+		ListHandovers(ctx *gin.Context)
+		ListTransitions(ctx *gin.Context)
 		Get(ctx *gin.Context)
 		Delete(ctx *gin.Context)
 		List(ctx *gin.Context)
+		ListByCat(ctx *gin.Context)
 		GetTarget(ctx *gin.Context)
 		DeleteTarget(ctx *gin.Context)
 		AddTarget(ctx *gin.Context)
 		UpdateTarget(ctx *gin.Context)
 	}
 
+	SkillHandlerInterface interface {
+		Create(ctx *gin.Context)
+		List(ctx *gin.Context)
+		ListCatSkills(ctx *gin.Context)
+		SetCatSkill(ctx *gin.Context)
+		RemoveCatSkill(ctx *gin.Context)
+	}
+
+	RankHandlerInterface interface {
+		List(ctx *gin.Context)
+		Update(ctx *gin.Context)
+	}
+
+	PayrollHandlerInterface interface {
+		CreateRun(ctx *gin.Context)
+		GetRun(ctx *gin.Context)
+		ExportRun(ctx *gin.Context)
+	}
+
+	PhotoHandlerInterface interface {
+		Upload(ctx *gin.Context)
+		Get(ctx *gin.Context)
+	}
+
+	MissionTemplateHandlerInterface interface {
+		Create(ctx *gin.Context)
+		Get(ctx *gin.Context)
+		List(ctx *gin.Context)
+		Update(ctx *gin.Context)
+		Delete(ctx *gin.Context)
+		Instantiate(ctx *gin.Context)
+	}
+
+	MissionTypeHandlerInterface interface {
+		List(ctx *gin.Context)
+		Create(ctx *gin.Context)
+		Update(ctx *gin.Context)
+	}
+
+	SearchHandlerInterface interface {
+		Search(ctx *gin.Context)
+	}
+
+	BreedHandlerInterface interface {
+		List(ctx *gin.Context)
+	}
+
+	BreedRegistryInterface interface {
+		IsKnownBreed(name string) bool
+	}
+
 	server struct {
-		logger         logger.Logger
-		router         *gin.Engine
-		catHandler     CatHandlerInterface
-		missionHandler MissionHandlerInterface
+		logger          logger.Logger
+		router          *gin.Engine
+		catHandler      CatHandlerInterface
+		missionHandler  MissionHandlerInterface
+		breedHandler    BreedHandlerInterface
+		skillHandler    SkillHandlerInterface
+		rankHandler     RankHandlerInterface
+		payrollHandler  PayrollHandlerInterface
+		photoHandler    PhotoHandlerInterface
+		searchHandler   SearchHandlerInterface
+		templateHandler MissionTemplateHandlerInterface
+		typeHandler     MissionTypeHandlerInterface
+		breedRegistry   BreedRegistryInterface
 	}
 )
 
-func New(customLogger logger.Logger, catH CatHandlerInterface, missionH MissionHandlerInterface) *server {
+func New(customLogger logger.Logger, catH CatHandlerInterface, missionH MissionHandlerInterface, breedH BreedHandlerInterface, skillH SkillHandlerInterface, rankH RankHandlerInterface, payrollH PayrollHandlerInterface, photoH PhotoHandlerInterface, searchH SearchHandlerInterface, templateH MissionTemplateHandlerInterface, typeH MissionTypeHandlerInterface, breedRegistry BreedRegistryInterface) *server {
 
 	s := &server{
-		logger:         customLogger,
-		router:         gin.Default(),
-		catHandler:     catH,
-		missionHandler: missionH,
+		logger:          customLogger,
+		router:          gin.Default(),
+		catHandler:      catH,
+		missionHandler:  missionH,
+		breedHandler:    breedH,
+		skillHandler:    skillH,
+		rankHandler:     rankH,
+		payrollHandler:  payrollH,
+		photoHandler:    photoH,
+		searchHandler:   searchH,
+		templateHandler: templateH,
+		typeHandler:     typeH,
+		breedRegistry:   breedRegistry,
 	}
 
 	s.setUpRoutes()
@@ -59,24 +146,82 @@ func New(customLogger logger.Logger, catH CatHandlerInterface, missionH MissionH
 func (s *server) setUpRoutes() {
 	catRoutes := s.router.Group("/cats")
 	catRoutes.POST("", s.catHandler.Hire)
+	catRoutes.POST("/import", s.catHandler.Import)
 	catRoutes.DELETE("/:id", s.catHandler.Fire)
+	catRoutes.POST("/:id/rehire", s.catHandler.Rehire)
+	catRoutes.POST("/:id/promote", s.catHandler.Promote)
 	catRoutes.GET("", s.catHandler.List)
+	catRoutes.GET("/stats", s.catHandler.BulkStats)
 	catRoutes.GET("/:id", s.catHandler.Get)
-	catRoutes.PATCH("/:id", s.catHandler.UpdateSalary)
+	catRoutes.PATCH("/:id", s.catHandler.Update)
+	catRoutes.POST("/:id/salary", s.catHandler.UpdateSalary)
+	catRoutes.GET("/:id/salary-history", s.catHandler.SalaryHistory)
+	catRoutes.PUT("/:id/photo", s.photoHandler.Upload)
+	catRoutes.GET("/:id/photo", s.photoHandler.Get)
+	catRoutes.GET("/:id/stats", s.catHandler.Stats)
+	catRoutes.GET("/:id/missions", s.missionHandler.ListByCat)
+	catRoutes.POST("/:id/leave", s.catHandler.AddLeave)
+	catRoutes.GET("/:id/leave", s.catHandler.ListLeaves)
+	catRoutes.DELETE("/:id/leave/:leaveId", s.catHandler.CancelLeave)
+	catRoutes.GET("/:id/skills", s.skillHandler.ListCatSkills)
+	catRoutes.PUT("/:id/skills/:skillId", s.skillHandler.SetCatSkill)
+	catRoutes.DELETE("/:id/skills/:skillId", s.skillHandler.RemoveCatSkill)
 
 	missionRoutes := s.router.Group("/missions")
 	missionRoutes.POST("", s.missionHandler.Add)
 	missionRoutes.PATCH("/:id", s.missionHandler.Update)
+	missionRoutes.POST("/:id/transitions", s.missionHandler.Transition)
+	missionRoutes.GET("/:id/transitions", s.missionHandler.ListTransitions)
+	missionRoutes.POST("/:id/unassign", s.missionHandler.Unassign)
+	missionRoutes.POST("/:id/reassign", s.missionHandler.Reassign)
+	missionRoutes.GET("/:id/handovers", s.missionHandler.ListHandovers)
+	missionRoutes.POST("/:id/members", s.missionHandler.AddMember)
+	missionRoutes.DELETE("/:id/members/:catId", s.missionHandler.RemoveMember)
+	missionRoutes.PUT("/:id/budget", s.missionHandler.SetBudget)
+	missionRoutes.POST("/:id/expenses", s.missionHandler.AddExpense)
+	missionRoutes.GET("/:id/expenses", s.missionHandler.ListExpenses)
+	missionRoutes.POST("/:id/clone", s.missionHandler.Clone)
 	missionRoutes.GET("/:id", s.missionHandler.Get)
 	missionRoutes.DELETE("/:id", s.missionHandler.Delete)
 	missionRoutes.GET("", s.missionHandler.List)
 
+	typeRoutes := s.router.Group("/mission-types")
+	typeRoutes.GET("", s.typeHandler.List)
+	typeRoutes.POST("", s.typeHandler.Create)
+	typeRoutes.PATCH("/:code", s.typeHandler.Update)
+
+	templateRoutes := s.router.Group("/mission-templates")
+	templateRoutes.POST("", s.templateHandler.Create)
+	templateRoutes.GET("", s.templateHandler.List)
+	templateRoutes.GET("/:id", s.templateHandler.Get)
+	templateRoutes.PUT("/:id", s.templateHandler.Update)
+	templateRoutes.DELETE("/:id", s.templateHandler.Delete)
+	templateRoutes.POST("/:id/instantiate", s.templateHandler.Instantiate)
+
 	targetRoutes := s.router.Group("targets")
 	targetRoutes.GET("/:id", s.missionHandler.GetTarget)
 	targetRoutes.DELETE("/:id", s.missionHandler.DeleteTarget)
 	targetRoutes.POST("", s.missionHandler.AddTarget)
 	targetRoutes.PATCH("/:id", s.missionHandler.UpdateTarget)
 
+	skillRoutes := s.router.Group("/skills")
+	skillRoutes.POST("", s.skillHandler.Create)
+	skillRoutes.GET("", s.skillHandler.List)
+
+	rankRoutes := s.router.Group("/ranks")
+	rankRoutes.GET("", s.rankHandler.List)
+	rankRoutes.PATCH("/:code", s.rankHandler.Update)
+
+	payrollRoutes := s.router.Group("/payroll")
+	payrollRoutes.POST("/runs", s.payrollHandler.CreateRun)
+	payrollRoutes.GET("/runs/:id", s.payrollHandler.GetRun)
+	payrollRoutes.GET("/runs/:id/export.csv", s.payrollHandler.ExportRun)
+
+	breedRoutes := s.router.Group("/breeds")
+	breedRoutes.GET("", s.breedHandler.List)
+
+	s.router.GET("/search", s.searchHandler.Search)
+
 }
 
 func (s *server) Run(serverPort string) {
@@ -85,53 +230,15 @@ func (s *server) Run(serverPort string) {
 	}
 }
 
-type BreedName struct {
-	Name string `json:"name"`
-}
-
 func (s *server) addBreedValidator() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		breedList, err := s.fetchBreedList()
-		if err != nil {
-			s.logger.Warnf("Failed to fetch breed info: %s", err.Error())
-			return
-		}
-
 		v.RegisterValidation("breed", func(fl validator.FieldLevel) bool {
 			breed, ok := fl.Field().Interface().(string)
 			if !ok {
 				return false
 			}
 
-			for _, v := range breedList {
-				if v.Name == breed {
-					return true
-				}
-			}
-			return false
+			return s.breedRegistry.IsKnownBreed(breed)
 		})
 	}
 }
-
-func (s *server) fetchBreedList() ([]BreedName, error) {
-	url := "https://api.thecatapi.com/v1/breeds"
-
-	client := &http.Client{Timeout: 5 * time.Second}
-	queryRes, err := client.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer queryRes.Body.Close()
-	body, err := io.ReadAll(queryRes.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var breedList []BreedName
-	err = json.Unmarshal(body, &breedList)
-	if err != nil {
-		return nil, err
-	}
-
-	return breedList, nil
-}